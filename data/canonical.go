@@ -0,0 +1,42 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+)
+
+// A Canonicalizer encodes values as canonical JSON. Implementations disagree
+// on details such as string escaping, so the canonicalizer used to compute
+// key IDs has to match the one used by the other party.
+type Canonicalizer interface {
+	Canonicalize(v interface{}) ([]byte, error)
+}
+
+// CanonicalizerFunc adapts a function to the Canonicalizer interface.
+type CanonicalizerFunc func(v interface{}) ([]byte, error)
+
+func (f CanonicalizerFunc) Canonicalize(v interface{}) ([]byte, error) {
+	return f(v)
+}
+
+// DefaultCanonicalizer is the securesystemslib (OLPC) canonical JSON form
+// used by python-tuf, which is what IDs uses.
+var DefaultCanonicalizer Canonicalizer = CanonicalizerFunc(cjson.EncodeCanonical)
+
+// IDsWith computes the IDs of the key with c rather than with the default
+// canonicalizer. Unlike IDs, the result is not cached.
+func (k *PublicKey) IDsWith(c Canonicalizer) ([]string, error) {
+	return computeKeyIDs(k, c)
+}
+
+func computeKeyIDs(k *PublicKey, c Canonicalizer) ([]string, error) {
+	data, err := c.Canonicalize(k)
+	if err != nil {
+		return nil, fmt.Errorf("tuf: error creating key ID: %w", err)
+	}
+	digest := sha256.Sum256(data)
+	return []string{hex.EncodeToString(digest[:])}, nil
+}
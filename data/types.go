@@ -2,26 +2,52 @@ package data
 
 import (
 	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/secure-systems-lab/go-securesystemslib/cjson"
 )
 
 const (
-	KeyIDLength                = sha256.Size * 2
-	KeyTypeEd25519             = "ed25519"
-	KeyTypeECDSA_SHA2_P256     = "ecdsa-sha2-nistp256"
-	KeySchemeEd25519           = "ed25519"
-	KeySchemeECDSA_SHA2_P256   = "ecdsa-sha2-nistp256"
-	KeyTypeRSASSA_PSS_SHA256   = "rsa"
-	KeySchemeRSASSA_PSS_SHA256 = "rsassa-pss-sha256"
+	KeyIDLength                  = sha256.Size * 2
+	KeyTypeEd25519               = "ed25519"
+	KeyTypeECDSA_SHA2_P256       = "ecdsa-sha2-nistp256"
+	KeySchemeEd25519             = "ed25519"
+	KeySchemeECDSA_SHA2_P256     = "ecdsa-sha2-nistp256"
+	KeyTypeRSASSA_PSS_SHA256     = "rsa"
+	KeySchemeRSASSA_PSS_SHA256   = "rsassa-pss-sha256"
+	KeySchemeRSA_PKCS1v15_SHA256 = "rsa-pkcs1v15-sha256"
+	KeyTypeECDSA_SHA2_P384       = "ecdsa-sha2-nistp384"
+	KeySchemeECDSA_SHA2_P384     = "ecdsa-sha2-nistp384"
+	KeyTypeECDSA_SHA2_P521       = "ecdsa-sha2-nistp521"
+	KeySchemeECDSA_SHA2_P521     = "ecdsa-sha2-nistp521"
+
+	// SHA3 key types sign the SHA3-256 digest of the message, for
+	// environments requiring SHA3 over SHA2.
+	KeyTypeECDSA_SHA3_P256   = "ecdsa-sha3-nistp256"
+	KeySchemeECDSA_SHA3_P256 = "ecdsa-sha3-nistp256"
+
+	// KeySchemeEd25519ctx is the scheme of KeyTypeEd25519 keys signing
+	// with Ed25519ctx (RFC 8032), whose signatures pure ed25519 does not
+	// accept.
+	KeySchemeEd25519ctx = "ed25519ctx"
+
+	// KeySchemeEd25519ph is the scheme of KeyTypeEd25519 keys signing
+	// with Ed25519ph (RFC 8032), the SHA-512 prehashed variant.
+	KeySchemeEd25519ph = "ed25519ph"
+
+	// KeyTypeECDSA is the generic ECDSA key type used by securesystemslib,
+	// where the curve is given by the scheme.
+	KeyTypeECDSA = "ecdsa"
+
+	// Brainpool key types are only usable when go-tuf is built with the
+	// "brainpool" build tag.
+	KeyTypeECDSA_SHA2_BrainpoolP256r1   = "ecdsa-sha2-brainpoolP256r1"
+	KeySchemeECDSA_SHA2_BrainpoolP256r1 = "ecdsa-sha2-brainpoolP256r1"
+	KeyTypeECDSA_SHA2_BrainpoolP384r1   = "ecdsa-sha2-brainpoolP384r1"
+	KeySchemeECDSA_SHA2_BrainpoolP384r1 = "ecdsa-sha2-brainpoolP384r1"
 )
 
 var (
@@ -45,6 +71,11 @@ type PublicKey struct {
 	Algorithms []string        `json:"keyid_hash_algorithms,omitempty"`
 	Value      json.RawMessage `json:"keyval"`
 
+	// Expires optionally records when the key stops being valid, for
+	// deployments that rotate keys on a schedule. It is part of the key
+	// object, so it changes the key IDs when set.
+	Expires *time.Time `json:"expires,omitempty"`
+
 	ids    []string
 	idOnce sync.Once
 }
@@ -54,16 +85,19 @@ type PrivateKey struct {
 	Scheme     string          `json:"scheme,omitempty"`
 	Algorithms []string        `json:"keyid_hash_algorithms,omitempty"`
 	Value      json.RawMessage `json:"keyval"`
+
+	// KeyID optionally records the ID of the public key, for key stores
+	// that keep it next to the private key.
+	KeyID string `json:"keyid,omitempty"`
 }
 
 func (k *PublicKey) IDs() []string {
 	k.idOnce.Do(func() {
-		data, err := cjson.EncodeCanonical(k)
+		ids, err := computeKeyIDs(k, DefaultCanonicalizer)
 		if err != nil {
-			panic(fmt.Errorf("tuf: error creating key ID: %w", err))
+			panic(err)
 		}
-		digest := sha256.Sum256(data)
-		k.ids = []string{hex.EncodeToString(digest[:])}
+		k.ids = ids
 	})
 	return k.ids
 }
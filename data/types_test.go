@@ -2,6 +2,7 @@ package data
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/secure-systems-lab/go-securesystemslib/cjson"
@@ -50,6 +51,64 @@ func (TypesSuite) TestKeyIDs(c *C) {
 	c.Assert(key.IDs(), DeepEquals, []string{keyid10algos})
 }
 
+// escapingCanonicalizer sorts object keys like the default canonicalizer
+// but escapes strings the way encoding/json does, including control
+// characters and "<", ">" and "&".
+var escapingCanonicalizer = CanonicalizerFunc(func(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+})
+
+func (TypesSuite) TestKeyIDsWithCanonicalizer(c *C) {
+	var hexbytes HexBytes
+	err := json.Unmarshal([]byte(public), &hexbytes)
+	c.Assert(err, IsNil)
+	keyValBytes, err := json.Marshal(ed25519Public{PublicKey: hexbytes})
+	c.Assert(err, IsNil)
+	key := &PublicKey{
+		Type:   KeyTypeEd25519,
+		Scheme: KeySchemeEd25519,
+		Value:  keyValBytes,
+	}
+
+	ids, err := key.IDsWith(DefaultCanonicalizer)
+	c.Assert(err, IsNil)
+	c.Assert(ids, DeepEquals, []string{keyid10})
+
+	// Nothing needs escaping in a hex encoded key, so both forms agree.
+	ids, err = key.IDsWith(escapingCanonicalizer)
+	c.Assert(err, IsNil)
+	c.Assert(ids, DeepEquals, []string{keyid10})
+
+	// PEM encoded keys contain newlines, which are only escaped by the
+	// second canonicalizer.
+	keyValBytes, err = json.Marshal(map[string]string{"public": "-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEA<&>\n-----END PUBLIC KEY-----\n"})
+	c.Assert(err, IsNil)
+	key = &PublicKey{
+		Type:   KeyTypeRSASSA_PSS_SHA256,
+		Scheme: KeySchemeRSASSA_PSS_SHA256,
+		Value:  keyValBytes,
+	}
+	defaultIDs, err := key.IDsWith(DefaultCanonicalizer)
+	c.Assert(err, IsNil)
+	c.Assert(defaultIDs, DeepEquals, key.IDs())
+	escapedIDs, err := key.IDsWith(escapingCanonicalizer)
+	c.Assert(err, IsNil)
+	c.Assert(escapedIDs, Not(DeepEquals), defaultIDs)
+
+	_, err = key.IDsWith(CanonicalizerFunc(func(interface{}) ([]byte, error) {
+		return nil, errors.New("boom")
+	}))
+	c.Assert(err, ErrorMatches, "tuf: error creating key ID: boom")
+}
+
 func (TypesSuite) TestRootAddKey(c *C) {
 	var hexbytes HexBytes
 	err := json.Unmarshal([]byte(public), &hexbytes)
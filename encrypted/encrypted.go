@@ -2,16 +2,19 @@
 // symmetrically with a passphrase.
 //
 // It uses scrypt derive a key from the passphrase and the NaCl secret box
-// cipher for authenticated encryption.
+// cipher for authenticated encryption, or optionally AES-256-GCM.
 package encrypted
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 
+	"github.com/theupdateframework/go-tuf/internal/zeroize"
 	"golang.org/x/crypto/nacl/secretbox"
 	"golang.org/x/crypto/scrypt"
 )
@@ -21,6 +24,8 @@ const saltSize = 32
 const (
 	boxKeySize   = 32
 	boxNonceSize = 24
+
+	gcmNonceSize = 12
 )
 
 const (
@@ -35,12 +40,23 @@ const (
 const (
 	nameScrypt    = "scrypt"
 	nameSecretBox = "nacl/secretbox"
+	nameAESGCM    = "aes-256-gcm"
+)
+
+// Ciphers supported by EncryptWithCipher.
+const (
+	CipherSecretBox = nameSecretBox
+	CipherAESGCM    = nameAESGCM
 )
 
+// ErrDecryptionFailed is returned by Decrypt when the ciphertext cannot be
+// authenticated, which usually means that the passphrase is wrong.
+var ErrDecryptionFailed = errors.New("encrypted: decryption failed")
+
 type data struct {
-	KDF        scryptKDF       `json:"kdf"`
-	Cipher     secretBoxCipher `json:"cipher"`
-	Ciphertext []byte          `json:"ciphertext"`
+	KDF        scryptKDF `json:"kdf"`
+	Cipher     boxCipher `json:"cipher"`
+	Ciphertext []byte    `json:"ciphertext"`
 }
 
 type scryptParams struct {
@@ -85,85 +101,129 @@ func (s *scryptKDF) CheckParams() error {
 	return nil
 }
 
-func newSecretBoxCipher() (secretBoxCipher, error) {
-	nonce := make([]byte, boxNonceSize)
+// nonceSize returns the nonce size of the named cipher, or 0 if the cipher
+// is not supported.
+func nonceSize(name string) int {
+	switch name {
+	case nameSecretBox:
+		return boxNonceSize
+	case nameAESGCM:
+		return gcmNonceSize
+	}
+	return 0
+}
+
+func newBoxCipher(name string) (boxCipher, error) {
+	size := nonceSize(name)
+	if size == 0 {
+		return boxCipher{}, fmt.Errorf("encrypted: unknown cipher name %q", name)
+	}
+	nonce := make([]byte, size)
 	if err := fillRandom(nonce); err != nil {
-		return secretBoxCipher{}, err
+		return boxCipher{}, err
 	}
-	return secretBoxCipher{
-		Name:  nameSecretBox,
+	return boxCipher{
+		Name:  name,
 		Nonce: nonce,
 	}, nil
 }
 
-type secretBoxCipher struct {
+// boxCipher is an authenticated cipher, NaCl secret box or AES-256-GCM,
+// along with the nonce it uses.
+type boxCipher struct {
 	Name  string `json:"name"`
 	Nonce []byte `json:"nonce"`
 
 	encrypted bool
 }
 
-func (s *secretBoxCipher) Encrypt(plaintext, key []byte) []byte {
-	var keyBytes [boxKeySize]byte
-	var nonceBytes [boxNonceSize]byte
-
-	if len(key) != len(keyBytes) {
+func (s *boxCipher) Encrypt(plaintext, key []byte) []byte {
+	if len(key) != boxKeySize {
 		panic("incorrect key size")
 	}
-	if len(s.Nonce) != len(nonceBytes) {
+	if len(s.Nonce) != nonceSize(s.Name) {
 		panic("incorrect nonce size")
 	}
 
-	copy(keyBytes[:], key)
-	copy(nonceBytes[:], s.Nonce)
-
 	// ensure that we don't re-use nonces
 	if s.encrypted {
 		panic("Encrypt must only be called once for each cipher instance")
 	}
 	s.encrypted = true
 
-	return secretbox.Seal(nil, plaintext, &nonceBytes, &keyBytes)
-}
-
-func (s *secretBoxCipher) Decrypt(ciphertext, key []byte) ([]byte, error) {
+	if s.Name == nameAESGCM {
+		return newGCM(key).Seal(nil, s.Nonce, plaintext, nil)
+	}
 	var keyBytes [boxKeySize]byte
 	var nonceBytes [boxNonceSize]byte
+	copy(keyBytes[:], key)
+	copy(nonceBytes[:], s.Nonce)
+	return secretbox.Seal(nil, plaintext, &nonceBytes, &keyBytes)
+}
 
-	if len(key) != len(keyBytes) {
+func (s *boxCipher) Decrypt(ciphertext, key []byte) ([]byte, error) {
+	if len(key) != boxKeySize {
 		panic("incorrect key size")
 	}
-	if len(s.Nonce) != len(nonceBytes) {
+	if len(s.Nonce) != nonceSize(s.Name) {
 		// return an error instead of panicking since the nonce is user input
 		return nil, errors.New("encrypted: incorrect nonce size")
 	}
 
+	if s.Name == nameAESGCM {
+		res, err := newGCM(key).Open(nil, s.Nonce, ciphertext, nil)
+		if err != nil {
+			return nil, ErrDecryptionFailed
+		}
+		return res, nil
+	}
+	var keyBytes [boxKeySize]byte
+	var nonceBytes [boxNonceSize]byte
 	copy(keyBytes[:], key)
 	copy(nonceBytes[:], s.Nonce)
-
 	res, ok := secretbox.Open(nil, ciphertext, &nonceBytes, &keyBytes)
 	if !ok {
-		return nil, errors.New("encrypted: decryption failed")
+		return nil, ErrDecryptionFailed
 	}
 	return res, nil
 }
 
+// newGCM returns AES-GCM with key, whose size was checked by the caller.
+func newGCM(key []byte) cipher.AEAD {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return gcm
+}
+
 // Encrypt takes a passphrase and plaintext, and returns a JSON object
 // containing ciphertext and the details necessary to decrypt it.
 func Encrypt(plaintext, passphrase []byte) ([]byte, error) {
-	k, err := newScryptKDF()
+	return EncryptWithCipher(plaintext, passphrase, CipherSecretBox)
+}
+
+// EncryptWithCipher is like Encrypt, but encrypts with the named cipher,
+// CipherSecretBox or CipherAESGCM. Decrypt supports both.
+func EncryptWithCipher(plaintext, passphrase []byte, cipherName string) ([]byte, error) {
+	c, err := newBoxCipher(cipherName)
 	if err != nil {
 		return nil, err
 	}
-	key, err := k.Key(passphrase)
+
+	k, err := newScryptKDF()
 	if err != nil {
 		return nil, err
 	}
-
-	c, err := newSecretBoxCipher()
+	key, err := k.Key(passphrase)
 	if err != nil {
 		return nil, err
 	}
+	defer zeroize.Bytes(key)
 
 	data := &data{
 		KDF:    k,
@@ -195,7 +255,7 @@ func Decrypt(ciphertext, passphrase []byte) ([]byte, error) {
 	if data.KDF.Name != nameScrypt {
 		return nil, fmt.Errorf("encrypted: unknown kdf name %q", data.KDF.Name)
 	}
-	if data.Cipher.Name != nameSecretBox {
+	if nonceSize(data.Cipher.Name) == 0 {
 		return nil, fmt.Errorf("encrypted: unknown cipher name %q", data.Cipher.Name)
 	}
 	if err := data.KDF.CheckParams(); err != nil {
@@ -206,6 +266,7 @@ func Decrypt(ciphertext, passphrase []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer zeroize.Bytes(key)
 
 	return data.Cipher.Decrypt(data.Ciphertext, key)
 }
@@ -61,3 +61,31 @@ func (EncryptedSuite) TestDecrypt(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(dec, DeepEquals, plaintext)
 }
+
+func (EncryptedSuite) TestAESGCMRoundtrip(c *C) {
+	passphrase := []byte("supersecret")
+
+	enc, err := EncryptWithCipher(plaintext, passphrase, CipherAESGCM)
+	c.Assert(err, IsNil)
+	data := &data{}
+	c.Assert(json.Unmarshal(enc, data), IsNil)
+	c.Assert(data.Cipher.Name, Equals, "aes-256-gcm")
+	c.Assert(data.Cipher.Nonce, HasLen, 12)
+
+	dec, err := Decrypt(enc, passphrase)
+	c.Assert(err, IsNil)
+	c.Assert(dec, DeepEquals, plaintext)
+
+	dec, err = Decrypt(enc, []byte("wrong"))
+	c.Assert(err, Equals, ErrDecryptionFailed)
+	c.Assert(dec, IsNil)
+
+	data.Ciphertext[0] = ^data.Ciphertext[0]
+	enc, _ = json.Marshal(data)
+	dec, err = Decrypt(enc, passphrase)
+	c.Assert(err, Equals, ErrDecryptionFailed)
+	c.Assert(dec, IsNil)
+
+	_, err = EncryptWithCipher(plaintext, passphrase, "rot13")
+	c.Assert(err, ErrorMatches, `encrypted: unknown cipher name "rot13"`)
+}
@@ -4,13 +4,16 @@ go 1.16
 
 require (
 	github.com/dustin/go-humanize v1.0.0
+	github.com/ebfe/brainpool v0.0.0-20130314170211-492e4d960f63
 	github.com/flynn/go-docopt v0.0.0-20140912013429-f6dd2ebbb31e
+	github.com/klauspost/compress v1.15.9
 	github.com/onsi/gomega v1.18.1 // indirect
 	github.com/secure-systems-lab/go-securesystemslib v0.3.1
 	github.com/stretchr/testify v1.7.1
 	github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7
-	golang.org/x/crypto v0.0.0-20211117183948-ae814b36b871
+	golang.org/x/crypto v0.0.0-20220331220935-ae2d96664a29
 	golang.org/x/net v0.0.0-20220127200216-cd36cc0744dd // indirect
 	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c
+	software.sslmate.com/src/go-pkcs12 v0.2.0
 )
@@ -0,0 +1,9 @@
+package zeroize
+
+// Bytes overwrites b with zeros, for secrets such as private keys and keys
+// derived from passphrases once they are no longer needed.
+func Bytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,15 @@
+package zeroize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	b := []byte("secret")
+	Bytes(b)
+	assert.Equal(t, make([]byte, 6), b)
+
+	Bytes(nil)
+}
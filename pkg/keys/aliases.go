@@ -0,0 +1,65 @@
+package keys
+
+import (
+	"errors"
+	"sync"
+)
+
+// algorithmAliases stores mapping between alias key type strings and the
+// primary key type they were registered with.
+var algorithmAliases sync.Map
+
+// RegisterAlgorithmAliases registers the verifier and signer constructors
+// under each of names, for key types known under several historical names.
+// The first name is the primary one, the others are recorded as its aliases
+// for GetUniqueAlgorithms. newSigner may be nil for verification-only
+// algorithms.
+//
+// All names are checked before any is registered, so that a failing call
+// does not leave a partial registration behind.
+func RegisterAlgorithmAliases(newVerifier func() Verifier, newSigner func() Signer, names ...string) error {
+	if newVerifier == nil {
+		return errors.New("tuf: nil verifier constructor")
+	}
+	if len(names) == 0 {
+		return errors.New("tuf: no algorithm names")
+	}
+	for _, name := range names {
+		if name == "" {
+			return errors.New("tuf: empty algorithm name")
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	primary := names[0]
+	for _, name := range names {
+		VerifierMap.Store(name, newVerifier)
+		if newSigner != nil {
+			SignerMap.Store(name, newSigner)
+		}
+		if name == primary {
+			algorithmAliases.Delete(name)
+		} else {
+			algorithmAliases.Store(name, primary)
+		}
+	}
+	return nil
+}
+
+// GetUniqueAlgorithms returns names with aliases replaced by their primary
+// name and duplicates removed, keeping the order of first appearance.
+func GetUniqueAlgorithms(names ...string) []string {
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if primary, ok := algorithmAliases.Load(name); ok {
+			name = primary.(string)
+		}
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,54 @@
+package keys
+
+import (
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type AliasesSuite struct{}
+
+var _ = Suite(&AliasesSuite{})
+
+func (AliasesSuite) TestRegisterAlgorithmAliases(c *C) {
+	defer SnapshotRegistry()()
+
+	err := RegisterAlgorithmAliases(NewP256Verifier, NewP256Signer, "ed25519-test", "ed25519-test-alias")
+	c.Assert(err, IsNil)
+
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	// Both names resolve to equivalent verifiers.
+	for _, name := range []string{"ed25519-test", "ed25519-test-alias"} {
+		pk := signer.PublicData()
+		pk.Type, pk.Scheme = name, name
+		verifier, err := GetVerifier(pk)
+		c.Assert(err, IsNil, Commentf("name = %s", name))
+		c.Assert(verifier.Verify(msg, sig), IsNil, Commentf("name = %s", name))
+	}
+	c.Assert(RequireAlgorithms("ed25519-test", "ed25519-test-alias"), IsNil)
+
+	c.Assert(GetUniqueAlgorithms("ed25519-test-alias", data.KeyTypeEd25519, "ed25519-test", data.KeyTypeEd25519),
+		DeepEquals, []string{"ed25519-test", data.KeyTypeEd25519})
+}
+
+func (AliasesSuite) TestRegisterAlgorithmAliasesNoPartialRegistration(c *C) {
+	defer SnapshotRegistry()()
+
+	err := RegisterAlgorithmAliases(NewP256Verifier, nil, "ed25519-test", "")
+	c.Assert(err, NotNil)
+	c.Assert(RequireAlgorithms("ed25519-test"), NotNil)
+
+	c.Assert(RegisterAlgorithmAliases(nil, nil, "ed25519-test"), NotNil)
+	c.Assert(RegisterAlgorithmAliases(NewP256Verifier, nil), NotNil)
+}
+
+func (AliasesSuite) TestSnapshotRestoresAliases(c *C) {
+	restore := SnapshotRegistry()
+	c.Assert(RegisterAlgorithmAliases(NewP256Verifier, nil, "ed25519-test", "ed25519-test-alias"), IsNil)
+	restore()
+	c.Assert(GetUniqueAlgorithms("ed25519-test-alias"), DeepEquals, []string{"ed25519-test-alias"})
+}
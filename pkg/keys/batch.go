@@ -0,0 +1,30 @@
+package keys
+
+import (
+	"fmt"
+)
+
+// SignBatch signs each of msgs with s, returning the signatures in the same
+// order. Signers implementing BatchSigner sign all the messages at once;
+// others sign them one by one. No signature is returned if any fails.
+func SignBatch(s Signer, msgs [][]byte) ([][]byte, error) {
+	if bs, ok := s.(BatchSigner); ok {
+		sigs, err := bs.SignBatch(msgs)
+		if err != nil {
+			return nil, err
+		}
+		if len(sigs) != len(msgs) {
+			return nil, fmt.Errorf("tuf: batch signer returned %d signatures for %d messages", len(sigs), len(msgs))
+		}
+		return sigs, nil
+	}
+	sigs := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		sig, err := s.SignMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = sig
+	}
+	return sigs, nil
+}
@@ -0,0 +1,55 @@
+package keys
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type BatchSuite struct{}
+
+var _ = Suite(&BatchSuite{})
+
+// fakeBatchSigner counts the round trips to a batch-capable backend.
+type fakeBatchSigner struct {
+	Signer
+	calls int
+	short bool
+}
+
+func (s *fakeBatchSigner) SignBatch(msgs [][]byte) ([][]byte, error) {
+	s.calls++
+	sigs := make([][]byte, 0, len(msgs))
+	for _, msg := range msgs {
+		sig, err := s.Signer.SignMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+	if s.short {
+		sigs = sigs[1:]
+	}
+	return sigs, nil
+}
+
+func (BatchSuite) TestSignBatch(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	msgs := [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")}
+
+	batch := &fakeBatchSigner{Signer: signer}
+	for _, s := range []Signer{batch, signer} {
+		sigs, err := SignBatch(s, msgs)
+		c.Assert(err, IsNil)
+		c.Assert(sigs, HasLen, len(msgs))
+		for i, msg := range msgs {
+			c.Assert(verifier.Verify(msg, sigs[i]), IsNil)
+		}
+	}
+	c.Assert(batch.calls, Equals, 1)
+
+	batch.short = true
+	_, err = SignBatch(batch, msgs)
+	c.Assert(err, ErrorMatches, "tuf: batch signer returned 2 signatures for 3 messages")
+}
@@ -0,0 +1,115 @@
+package keys
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// A VerificationCache remembers successful signature verifications, so that
+// verifying the same signature of the same message with the same key again
+// does not repeat the cryptographic operations. Failed verifications are
+// never cached: an invalid signature is checked again every time, and
+// cannot evict valid entries by being repeated.
+//
+// A VerificationCache is safe for concurrent use.
+type VerificationCache struct {
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+// NewVerificationCache returns a VerificationCache holding up to size
+// verifications, evicting the least recently used ones first. A size of 0
+// or less disables caching.
+func NewVerificationCache(size int) *VerificationCache {
+	return &VerificationCache{
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// Verify verifies sig over msg with v like v.Verify, unless the same
+// verification already succeeded. Verifications are identified by the
+// variant of v, such as pure Ed25519 or Ed25519ctx, its context if any, the
+// key ID of its key, the SHA-256 digest of msg and sig, so that a success
+// with one variant never vouches for another. Verifiers without a public
+// key fail with ErrInvalidKey.
+func (c *VerificationCache) Verify(v Verifier, msg, sig []byte) error {
+	if c.size <= 0 {
+		return v.Verify(msg, sig)
+	}
+	key, err := verificationCacheKey(v, msg, sig)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	if err := v.Verify(msg, sig); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		// Added by a concurrent verification.
+		c.lru.MoveToFront(e)
+		return nil
+	}
+	c.entries[key] = c.lru.PushFront(key)
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.([sha256.Size]byte))
+	}
+	return nil
+}
+
+// Len returns the number of verifications in the cache.
+func (c *VerificationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// A contextVerifier is a Verifier bound to a context, such as an
+// Ed25519ctx verifier, whose signatures only verify with the same context.
+type contextVerifier interface {
+	verifierContext() string
+}
+
+// verificationCacheKey hashes the variant of v, given by its type, its
+// context, the key ID of its key, the digest of msg and sig, each prefixed
+// with its length so that different tuples cannot collide.
+func verificationCacheKey(v Verifier, msg, sig []byte) ([sha256.Size]byte, error) {
+	var key [sha256.Size]byte
+	pk := v.MarshalPublicKey()
+	if pk == nil {
+		return key, fmt.Errorf("%w: verifier has no public key", ErrInvalidKey)
+	}
+	var context string
+	if cv, ok := v.(contextVerifier); ok {
+		context = cv.verifierContext()
+	}
+	digest := sha256.Sum256(msg)
+	h := sha256.New()
+	for _, b := range [][]byte{[]byte(fmt.Sprintf("%T", v)), []byte(context), []byte(pk.IDs()[0]), digest[:], sig} {
+		var n [8]byte
+		binary.BigEndian.PutUint64(n[:], uint64(len(b)))
+		h.Write(n[:])
+		h.Write(b)
+	}
+	copy(key[:], h.Sum(nil))
+	return key, nil
+}
@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type CacheSuite struct{}
+
+var _ = Suite(&CacheSuite{})
+
+// countingVerifier counts the verifications reaching the wrapped Verifier.
+type countingVerifier struct {
+	Verifier
+	calls int32
+}
+
+func (v *countingVerifier) Verify(msg, sig []byte) error {
+	atomic.AddInt32(&v.calls, 1)
+	return v.Verifier.Verify(msg, sig)
+}
+
+func newCountingVerifier(c *C) (*countingVerifier, Signer) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	return &countingVerifier{Verifier: verifier}, signer
+}
+
+func (CacheSuite) TestVerificationCache(c *C) {
+	verifier, signer := newCountingVerifier(c)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	cache := NewVerificationCache(10)
+	c.Assert(cache.Verify(verifier, msg, sig), IsNil)
+	c.Assert(verifier.calls, Equals, int32(1))
+	c.Assert(cache.Verify(verifier, msg, sig), IsNil)
+	c.Assert(verifier.calls, Equals, int32(1))
+	c.Assert(cache.Len(), Equals, 1)
+
+	// Another message is verified.
+	c.Assert(cache.Verify(verifier, []byte("bar"), sig), NotNil)
+	c.Assert(verifier.calls, Equals, int32(2))
+
+	// Failures are not cached.
+	bad := append([]byte{}, sig...)
+	bad[0] ^= 1
+	c.Assert(cache.Verify(verifier, msg, bad), NotNil)
+	c.Assert(cache.Verify(verifier, msg, bad), NotNil)
+	c.Assert(verifier.calls, Equals, int32(4))
+	c.Assert(cache.Len(), Equals, 1)
+
+	// Another key is verified too.
+	other, _ := newCountingVerifier(c)
+	c.Assert(cache.Verify(other, msg, sig), NotNil)
+	c.Assert(other.calls, Equals, int32(1))
+}
+
+func (CacheSuite) TestVerificationCacheEviction(c *C) {
+	verifier, signer := newCountingVerifier(c)
+	msgs := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	sigs := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+		sigs[i] = sig
+	}
+
+	cache := NewVerificationCache(2)
+	c.Assert(cache.Verify(verifier, msgs[0], sigs[0]), IsNil)
+	c.Assert(cache.Verify(verifier, msgs[1], sigs[1]), IsNil)
+	// Use "a", so that "b" is the least recently used.
+	c.Assert(cache.Verify(verifier, msgs[0], sigs[0]), IsNil)
+	c.Assert(cache.Verify(verifier, msgs[2], sigs[2]), IsNil)
+	c.Assert(verifier.calls, Equals, int32(3))
+	c.Assert(cache.Len(), Equals, 2)
+
+	c.Assert(cache.Verify(verifier, msgs[0], sigs[0]), IsNil)
+	c.Assert(verifier.calls, Equals, int32(3))
+	c.Assert(cache.Verify(verifier, msgs[1], sigs[1]), IsNil)
+	c.Assert(verifier.calls, Equals, int32(4))
+
+	// A disabled cache verifies every time.
+	disabled := NewVerificationCache(0)
+	c.Assert(disabled.Verify(verifier, msgs[0], sigs[0]), IsNil)
+	c.Assert(disabled.Verify(verifier, msgs[0], sigs[0]), IsNil)
+	c.Assert(verifier.calls, Equals, int32(6))
+	c.Assert(disabled.Len(), Equals, 0)
+}
+
+func (CacheSuite) TestVerificationCacheVariants(c *C) {
+	if err := checkEd25519Options(); err != nil {
+		c.Skip(err.Error())
+	}
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	signerA, err := NewEd25519ContextSigner(signer, []byte("a"))
+	c.Assert(err, IsNil)
+	verifierA, err := NewEd25519ContextVerifier(verifier, []byte("a"))
+	c.Assert(err, IsNil)
+	verifierB, err := NewEd25519ContextVerifier(verifier, []byte("b"))
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	sig, err := signerA.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	// A success cached for one context does not vouch for another context
+	// or for pure ed25519.
+	cache := NewVerificationCache(10)
+	c.Assert(cache.Verify(verifierA, msg, sig), IsNil)
+	err = cache.Verify(verifierB, msg, sig)
+	c.Assert(errors.Is(err, ErrInvalid), Equals, true)
+	err = cache.Verify(verifier, msg, sig)
+	c.Assert(errors.Is(err, ErrInvalid), Equals, true)
+	c.Assert(cache.Len(), Equals, 1)
+}
+
+// nilKeyVerifier is a custom Verifier without a public key.
+type nilKeyVerifier struct {
+	Verifier
+}
+
+func (nilKeyVerifier) MarshalPublicKey() *data.PublicKey {
+	return nil
+}
+
+func (CacheSuite) TestVerificationCacheNoPublicKey(c *C) {
+	verifier, signer := newCountingVerifier(c)
+	sig, err := signer.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+
+	err = NewVerificationCache(10).Verify(nilKeyVerifier{verifier}, []byte("foo"), sig)
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+}
@@ -0,0 +1,78 @@
+package keys
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrDecompressedTooLarge is returned by VerifyCompressed when the content
+// decompresses to more than the allowed size.
+var ErrDecompressedTooLarge = errors.New("tuf: decompressed content exceeds the size limit")
+
+// zstdMinWindow is the smallest window size zstd streams are allowed to use
+// whatever the size limit, the window of zstd encoders at their default
+// compression levels.
+const zstdMinWindow = 8 << 20
+
+// VerifyCompressed verifies that sig is a signature by v of the content read
+// from compressed after decompressing it with codec, "gzip" or "zstd". The
+// content is decompressed on the fly, as with VerifyReader, and the
+// verification fails with ErrDecompressedTooLarge as soon as it exceeds
+// maxSize bytes, which protects against decompression bombs. The window of
+// zstd streams, which the decoder keeps in memory, is limited to maxSize or
+// 8 MiB, whichever is larger.
+func VerifyCompressed(v Verifier, compressed io.Reader, codec string, sig []byte, maxSize int64) error {
+	if maxSize <= 0 {
+		return fmt.Errorf("%w: maximum decompressed size must be positive", ErrInvalidArgument)
+	}
+	var (
+		r   io.ReadCloser
+		err error
+	)
+	switch codec {
+	case "gzip":
+		r, err = gzip.NewReader(compressed)
+	case "zstd":
+		window := uint64(maxSize)
+		if window < zstdMinWindow {
+			window = zstdMinWindow
+		}
+		var d *zstd.Decoder
+		d, err = zstd.NewReader(compressed, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(window))
+		if err == nil {
+			r = d.IOReadCloser()
+		}
+	default:
+		return fmt.Errorf("%w: unsupported compression codec %q", ErrInvalidArgument, codec)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return VerifyReader(v, &maxSizeReader{r: r, max: maxSize}, sig)
+}
+
+// maxSizeReader fails with ErrDecompressedTooLarge once more than max bytes
+// have been read from r.
+type maxSizeReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	// Never read more than one byte past the limit.
+	if remaining := m.max - m.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := m.r.Read(p)
+	m.read += int64(n)
+	if m.read > m.max {
+		return 0, ErrDecompressedTooLarge
+	}
+	return n, err
+}
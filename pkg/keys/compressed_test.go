@@ -0,0 +1,109 @@
+package keys
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	. "gopkg.in/check.v1"
+)
+
+type CompressedSuite struct{}
+
+var _ = Suite(&CompressedSuite{})
+
+func compress(c *C, codec string, msg []byte) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch codec {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "zstd":
+		zw, err := zstd.NewWriter(&buf)
+		c.Assert(err, IsNil)
+		w = zw
+	}
+	_, err := w.Write(msg)
+	c.Assert(err, IsNil)
+	c.Assert(w.Close(), IsNil)
+	return buf.Bytes()
+}
+
+func (CompressedSuite) TestVerifyCompressed(c *C) {
+	msg := bytes.Repeat([]byte("foo"), 1000)
+	for _, gen := range []func() (Signer, error){
+		func() (Signer, error) { return GenerateEd25519Key() },
+		func() (Signer, error) { return GenerateEcdsaKey() },
+	} {
+		signer, err := gen()
+		c.Assert(err, IsNil)
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+
+		for _, codec := range []string{"gzip", "zstd"} {
+			compressed := compress(c, codec, msg)
+			c.Assert(VerifyCompressed(verifier, bytes.NewReader(compressed), codec, sig, int64(len(msg))), IsNil)
+
+			other := compress(c, codec, []byte("bar"))
+			c.Assert(VerifyCompressed(verifier, bytes.NewReader(other), codec, sig, int64(len(msg))), NotNil)
+
+			err := VerifyCompressed(verifier, bytes.NewReader(compressed), codec, sig, int64(len(msg))-1)
+			c.Assert(err, Equals, ErrDecompressedTooLarge)
+		}
+	}
+}
+
+func (CompressedSuite) TestDecompressionBomb(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+
+	// 64 MiB of zeros compress to about 64 KiB.
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	chunk := make([]byte, 1<<20)
+	for i := 0; i < 64; i++ {
+		_, err := w.Write(chunk)
+		c.Assert(err, IsNil)
+	}
+	c.Assert(w.Close(), IsNil)
+
+	err = VerifyCompressed(verifier, &buf, "gzip", []byte{0}, 1<<20)
+	c.Assert(err, Equals, ErrDecompressedTooLarge)
+
+	bomb := compress(c, "zstd", make([]byte, 64<<20))
+	err = VerifyCompressed(verifier, bytes.NewReader(bomb), "zstd", []byte{0}, 1<<20)
+	c.Assert(err, Equals, ErrDecompressedTooLarge)
+
+	// zstd streams whose window is larger than the limit are rejected
+	// before decompressing anything.
+	var large bytes.Buffer
+	zw, err := zstd.NewWriter(&large, zstd.WithWindowSize(64<<20))
+	c.Assert(err, IsNil)
+	for i := 0; i < 64; i++ {
+		_, err := zw.Write(chunk)
+		c.Assert(err, IsNil)
+	}
+	c.Assert(zw.Close(), IsNil)
+	err = VerifyCompressed(verifier, &large, "zstd", []byte{0}, 1<<20)
+	c.Assert(errors.Is(err, zstd.ErrWindowSizeExceeded), Equals, true)
+}
+
+func (CompressedSuite) TestInvalidArguments(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+
+	err = VerifyCompressed(verifier, bytes.NewReader(nil), "zlib", nil, 1)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	err = VerifyCompressed(verifier, bytes.NewReader(nil), "gzip", nil, 0)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	err = VerifyCompressed(verifier, bytes.NewReader([]byte("foo")), "gzip", nil, 1)
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,66 @@
+package keys
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type ConcurrencySuite struct{}
+
+var _ = Suite(&ConcurrencySuite{})
+
+// TestSharedInstances signs and verifies distinct messages from many
+// goroutines with the same Signer and Verifier instances, to catch data
+// races, with -race, and results mixed up between goroutines through state
+// shared by instances, such as the pool of ECDSA signatures.
+func (ConcurrencySuite) TestSharedInstances(c *C) {
+	const (
+		goroutines = 32
+		iterations = 20
+	)
+
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeEd25519} {
+		priv, err := GenerateRandom(keyType, nil)
+		c.Assert(err, IsNil)
+		signer, err := GetSigner(priv)
+		c.Assert(err, IsNil)
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, goroutines)
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				errs <- signAndVerifyConcurrently(signer, verifier, g, iterations)
+			}(g)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			c.Assert(err, IsNil, Commentf("%s", keyType))
+		}
+	}
+}
+
+func signAndVerifyConcurrently(signer Signer, verifier Verifier, g, iterations int) error {
+	for i := 0; i < iterations; i++ {
+		msg := []byte(fmt.Sprintf("message %d from goroutine %d", i, g))
+		sig, err := signer.SignMessage(msg)
+		if err != nil {
+			return err
+		}
+		if err := verifier.Verify(msg, sig); err != nil {
+			return fmt.Errorf("goroutine %d: valid signature %d rejected: %w", g, i, err)
+		}
+		other := []byte(fmt.Sprintf("message %d from goroutine %d", i, g+1))
+		if err := verifier.Verify(other, sig); err == nil {
+			return fmt.Errorf("goroutine %d: signature %d accepted for another message", g, i)
+		}
+	}
+	return nil
+}
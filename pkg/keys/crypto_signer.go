@@ -0,0 +1,109 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// NewCryptoSigner returns a Signer backed by s, a crypto.Signer such as a
+// key held in an HSM or a KMS, whose public key is ed25519, ECDSA on a
+// registered curve or RSA. The signatures are those of the signers of this
+// package for the same key type.
+//
+// ECDSA crypto.Signers are meant to return ASN.1 DER signatures, but some
+// return raw r||s ones, and some do not encode integers minimally: ECDSA
+// signatures are re-encoded in minimal DER, the format verifiers of this
+// package expect.
+//
+// The private key stays in s: MarshalPrivateKey and UnmarshalPrivateKey
+// return an error.
+func NewCryptoSigner(s crypto.Signer) (Signer, error) {
+	public, err := publicKeyFromCrypto(s.Public())
+	if err != nil {
+		return nil, err
+	}
+	cs := &cryptoSigner{signer: s, public: public}
+	switch k := s.Public().(type) {
+	case *ecdsa.PublicKey:
+		_, params, err := ecdsaKeyTypeForCurve(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		cs.hash = params.hash
+		cs.ecdsaKeySize = curveByteSize(k.Curve)
+	case *rsa.PublicKey:
+		cs.hash = crypto.SHA256
+	}
+	return cs, nil
+}
+
+type cryptoSigner struct {
+	signer crypto.Signer
+	public *data.PublicKey
+
+	// hash is the hash function applied to messages before signing them,
+	// zero for ed25519.
+	hash crypto.Hash
+	// ecdsaKeySize is the curve size of ECDSA keys, zero for other keys.
+	ecdsaKeySize int
+}
+
+func (s *cryptoSigner) SignMessage(message []byte) ([]byte, error) {
+	if s.hash == 0 {
+		return s.signer.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+	h := s.hash.New()
+	h.Write(message)
+	digest := h.Sum(nil)
+
+	if s.ecdsaKeySize == 0 {
+		return s.signer.Sign(rand.Reader, digest, &rsa.PSSOptions{Hash: s.hash})
+	}
+	sig, err := s.signer.Sign(rand.Reader, digest, s.hash)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeEcdsaSignature(sig, s.ecdsaKeySize)
+}
+
+// normalizeEcdsaSignature returns sig, an ECDSA signature in either DER or
+// raw r||s form, in minimal DER.
+func normalizeEcdsaSignature(sig []byte, keySize int) ([]byte, error) {
+	raw, err := EcdsaDERToRaw(sig, keySize)
+	if err != nil {
+		if len(sig) != 2*keySize {
+			return nil, fmt.Errorf("tuf: malformed ecdsa signature from crypto.Signer: %w", err)
+		}
+		raw = sig
+	}
+	return EcdsaRawToDER(raw, keySize)
+}
+
+// deterministic reports true for ed25519 keys only, as whether other
+// crypto.Signers derive their nonces, as in RFC 6979, cannot be told.
+func (s *cryptoSigner) deterministic() bool {
+	return s.hash == 0
+}
+
+func (s *cryptoSigner) PublicData() *data.PublicKey {
+	return &data.PublicKey{
+		Type:       s.public.Type,
+		Scheme:     s.public.Scheme,
+		Algorithms: s.public.Algorithms,
+		Value:      s.public.Value,
+	}
+}
+
+func (s *cryptoSigner) MarshalPrivateKey() (*data.PrivateKey, error) {
+	return nil, errors.New("tuf: the private key of a crypto.Signer cannot be exported")
+}
+
+func (s *cryptoSigner) UnmarshalPrivateKey(key *data.PrivateKey) error {
+	return errors.New("tuf: a crypto.Signer cannot load a private key")
+}
@@ -0,0 +1,87 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+
+	. "gopkg.in/check.v1"
+)
+
+type CryptoSignerSuite struct{}
+
+var _ = Suite(&CryptoSignerSuite{})
+
+// rawEcdsaSigner is a crypto.Signer returning raw r||s signatures, like
+// some PKCS#11 based signers.
+type rawEcdsaSigner struct {
+	*ecdsa.PrivateKey
+}
+
+func (s rawEcdsaSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	der, err := s.PrivateKey.Sign(rand, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+	return EcdsaDERToRaw(der, curveByteSize(s.Curve))
+}
+
+func (CryptoSignerSuite) TestSignVerify(c *C) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, IsNil)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	c.Assert(err, IsNil)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	for _, t := range []struct {
+		name   string
+		signer crypto.Signer
+	}{
+		{"ed25519", edKey},
+		{"ecdsa p256 der", p256},
+		{"ecdsa p384 der", p384},
+		{"ecdsa p256 raw", rawEcdsaSigner{p256}},
+		{"rsa", rsaKey},
+	} {
+		comment := Commentf("signer = %s", t.name)
+		signer, err := NewCryptoSigner(t.signer)
+		c.Assert(err, IsNil, comment)
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil, comment)
+
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil, comment)
+		c.Assert(verifier.Verify(msg, sig), IsNil, comment)
+		c.Assert(verifier.Verify([]byte("bar"), sig), NotNil, comment)
+
+		_, err = signer.MarshalPrivateKey()
+		c.Assert(err, NotNil, comment)
+	}
+}
+
+func (CryptoSignerSuite) TestNormalizeEcdsaSignature(c *C) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	digest := make([]byte, 32)
+	der, err := ecdsa.SignASN1(rand.Reader, key, digest)
+	c.Assert(err, IsNil)
+	raw, err := EcdsaDERToRaw(der, 32)
+	c.Assert(err, IsNil)
+
+	for _, sig := range [][]byte{der, raw} {
+		normalized, err := normalizeEcdsaSignature(sig, 32)
+		c.Assert(err, IsNil)
+		c.Assert(normalized, DeepEquals, der)
+	}
+
+	_, err = normalizeEcdsaSignature(der[:len(der)-1], 32)
+	c.Assert(err, ErrorMatches, "tuf: malformed ecdsa signature from crypto.Signer: .*")
+}
@@ -0,0 +1,84 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+const (
+	slip10Ed25519Curve = "ed25519 seed"
+	slip10Hardened     = uint32(1) << 31
+)
+
+// DeriveEd25519 derives an ed25519 private key from seed along path, as
+// specified by SLIP-0010. Only hardened derivation is defined for ed25519,
+// so every path element must be hardened, e.g. "m/44'/0'/1'" (an "H" suffix
+// is accepted too).
+func DeriveEd25519(seed []byte, path string) (*data.PrivateKey, error) {
+	key, _, err := slip10DeriveEd25519(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	priv := ed25519.NewKeyFromSeed(key)
+	return NewEd25519Signer(Ed25519PrivateKeyValue{
+		Public:  data.HexBytes(priv.Public().(ed25519.PublicKey)),
+		Private: data.HexBytes(priv),
+	}).MarshalPrivateKey()
+}
+
+// slip10DeriveEd25519 returns the private key seed and chain code at path.
+func slip10DeriveEd25519(seed []byte, path string) ([]byte, []byte, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, nil, fmt.Errorf("%w: seed must be between 16 and 64 bytes", ErrInvalidArgument)
+	}
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mac := hmac.New(sha512.New, []byte(slip10Ed25519Curve))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chain := sum[:32], sum[32:]
+
+	for _, index := range indexes {
+		buf := make([]byte, 1+32+4)
+		copy(buf[1:], key)
+		binary.BigEndian.PutUint32(buf[33:], index)
+
+		mac = hmac.New(sha512.New, chain)
+		mac.Write(buf)
+		sum = mac.Sum(nil)
+		key, chain = sum[:32], sum[32:]
+	}
+	return key, chain, nil
+}
+
+// parseDerivationPath parses a path of the form "m/0'/1'" into hardened
+// child indexes.
+func parseDerivationPath(path string) ([]uint32, error) {
+	elems := strings.Split(path, "/")
+	if elems[0] != "m" {
+		return nil, fmt.Errorf("%w: derivation path %q must start with \"m\"", ErrInvalidArgument, path)
+	}
+	indexes := make([]uint32, 0, len(elems)-1)
+	for _, elem := range elems[1:] {
+		trimmed := strings.TrimRight(elem, "'H")
+		if len(elem)-len(trimmed) != 1 {
+			return nil, fmt.Errorf("%w: derivation path element %q is not hardened", ErrInvalidArgument, elem)
+		}
+		n, err := strconv.ParseUint(trimmed, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid derivation path element %q", ErrInvalidArgument, elem)
+		}
+		indexes = append(indexes, uint32(n)|slip10Hardened)
+	}
+	return indexes, nil
+}
@@ -0,0 +1,109 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type DeriveSuite struct{}
+
+var _ = Suite(&DeriveSuite{})
+
+// SLIP-0010 test vectors for ed25519.
+var slip10Vectors = []struct {
+	seed  string
+	path  string
+	chain string
+	priv  string
+	pub   string
+}{
+	{
+		seed:  "000102030405060708090a0b0c0d0e0f",
+		path:  "m",
+		chain: "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+		priv:  "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		pub:   "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed",
+	},
+	{
+		seed:  "000102030405060708090a0b0c0d0e0f",
+		path:  "m/0'",
+		chain: "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+		priv:  "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		pub:   "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c",
+	},
+	{
+		seed: "000102030405060708090a0b0c0d0e0f",
+		path: "m/0H/1H/2H",
+		priv: "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+		pub:  "ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1",
+	},
+	{
+		seed: "000102030405060708090a0b0c0d0e0f",
+		path: "m/0'/1'/2'/2'",
+		priv: "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662",
+		pub:  "8abae2d66361c879b900d204ad2cc4984fa2aa344dd7ddc46007329ac76c429c",
+	},
+	{
+		seed: "000102030405060708090a0b0c0d0e0f",
+		path: "m/0'/1'/2'/2'/1000000000'",
+		priv: "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793",
+		pub:  "3c24da049451555d51a7014a37337aa4e12d41e485abccfa46b47dfb2af54b7a",
+	},
+	{
+		seed: "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+		path: "m",
+		priv: "171cb88b1b3c1db25add599712e36245d75bc65a1a5c9e18d76f9f2b1eab4012",
+		pub:  "8fe9693f8fa62a4305a140b9764c5ee01e455963744fe18204b4fb948249308a",
+	},
+	{
+		seed: "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+		path: "m/0'",
+		priv: "1559eb2bbec5790b0c65d8693e4d0875b1747f4970ae8b650486ed7470845635",
+		pub:  "86fab68dcb57aa196c77c5f264f215a112c22a912c10d123b0d03c3c28ef1037",
+	},
+}
+
+func (DeriveSuite) TestVectors(c *C) {
+	for _, v := range slip10Vectors {
+		seed, err := hex.DecodeString(v.seed)
+		c.Assert(err, IsNil)
+
+		key, chain, err := slip10DeriveEd25519(seed, v.path)
+		c.Assert(err, IsNil)
+		c.Assert(hex.EncodeToString(key), Equals, v.priv, Commentf("path = %s", v.path))
+		if v.chain != "" {
+			c.Assert(hex.EncodeToString(chain), Equals, v.chain, Commentf("path = %s", v.path))
+		}
+
+		pk, err := DeriveEd25519(seed, v.path)
+		c.Assert(err, IsNil)
+		var value Ed25519PrivateKeyValue
+		c.Assert(json.Unmarshal(pk.Value, &value), IsNil)
+		c.Assert(hex.EncodeToString(value.Public), Equals, v.pub, Commentf("path = %s", v.path))
+		c.Assert(ed25519.PrivateKey(value.Private).Seed(), DeepEquals, key)
+
+		signer, err := GetSigner(pk)
+		c.Assert(err, IsNil)
+		c.Assert(signer.PublicData().Type, Equals, data.KeyTypeEd25519)
+	}
+}
+
+func (DeriveSuite) TestInvalidPaths(c *C) {
+	seed := make([]byte, 32)
+	for _, path := range []string{"", "0'", "m/0", "m/0'/1", "m/a'", "m/0''", "m/2147483648'", "m/"} {
+		_, err := DeriveEd25519(seed, path)
+		c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true, Commentf("path = %q", path))
+	}
+}
+
+func (DeriveSuite) TestInvalidSeed(c *C) {
+	_, err := DeriveEd25519(make([]byte, 15), "m")
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	_, err = DeriveEd25519(make([]byte, 65), "m")
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+}
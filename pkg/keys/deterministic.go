@@ -0,0 +1,20 @@
+package keys
+
+// deterministicSigner is implemented by signers that can tell whether they
+// always produce the same signature for the same message.
+type deterministicSigner interface {
+	deterministic() bool
+}
+
+// Deterministic reports whether signing the same message twice with s yields
+// the same signature, so that, for example, content-addressed stores can
+// deduplicate signatures. It is true for ed25519 signers, including Ed25519ph
+// and Ed25519ctx ones, and false for ECDSA signers, whose nonces are random,
+// and RSASSA-PSS signers, whose salts are random. Signers that cannot tell,
+// such as the ones of custom key types, are reported as not deterministic.
+func Deterministic(s Signer) bool {
+	if d, ok := s.(deterministicSigner); ok {
+		return d.deterministic()
+	}
+	return false
+}
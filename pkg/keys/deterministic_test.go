@@ -0,0 +1,81 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type DeterministicSuite struct{}
+
+var _ = Suite(&DeterministicSuite{})
+
+// assertDeterministic checks that Deterministic(s) is expected, and that it
+// tells whether two signatures of the same message are identical.
+func assertDeterministic(c *C, s Signer, expected bool) {
+	c.Assert(Deterministic(s), Equals, expected, Commentf("%s", s.PublicData().Type))
+	msg := []byte("foo")
+	first, err := s.SignMessage(msg)
+	c.Assert(err, IsNil)
+	second, err := s.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(bytes.Equal(first, second), Equals, expected, Commentf("%s", s.PublicData().Type))
+}
+
+func (DeterministicSuite) TestRegisteredKeyTypes(c *C) {
+	tested := 0
+	SignerMap.Range(func(k, _ interface{}) bool {
+		keyType := k.(string)
+		priv, err := GenerateRandom(keyType, nil)
+		if errors.Is(err, ErrUnsupportedKeyType) {
+			return true
+		}
+		c.Assert(err, IsNil)
+		signer, err := GetSigner(priv)
+		c.Assert(err, IsNil)
+		_, isEd25519 := signer.(*ed25519Signer)
+		assertDeterministic(c, signer, isEd25519)
+		tested++
+		return true
+	})
+	c.Assert(tested > 2, Equals, true)
+
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	assertDeterministic(c, rsa, false)
+	pkcs1, err := GenerateRsaKeyWithScheme(data.KeySchemeRSA_PKCS1v15_SHA256)
+	c.Assert(err, IsNil)
+	assertDeterministic(c, pkcs1, true)
+}
+
+func (DeterministicSuite) TestWrappedSigners(c *C) {
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	assertDeterministic(c, InstrumentSigner(ed, &recordingObserver{}), true)
+
+	ec, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	assertDeterministic(c, InstrumentSigner(ec, &recordingObserver{}), false)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, IsNil)
+	s, err := NewCryptoSigner(edKey)
+	c.Assert(err, IsNil)
+	assertDeterministic(c, s, true)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	s, err = NewCryptoSigner(ecKey)
+	c.Assert(err, IsNil)
+	assertDeterministic(c, s, false)
+
+	// Signers of other packages, which cannot tell, are reported as
+	// randomized.
+	c.Assert(Deterministic(&slowSigner{Signer: ed}), Equals, false)
+}
@@ -0,0 +1,69 @@
+package keys
+
+import (
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// signatureFormatChecker is implemented by verifiers able to check the
+// structure of a signature without performing any cryptographic operation.
+type signatureFormatChecker interface {
+	checkSignatureFormat(sig []byte) error
+}
+
+// VerifyResult details the outcome of each step of a signature
+// verification performed by VerifyDiagnose.
+type VerifyResult struct {
+	// KeyTypeSupported is true if a verifier is registered for the key type.
+	KeyTypeSupported bool
+	// KeyValid is true if the key value could be parsed for its key type.
+	KeyValid bool
+	// SignatureFormatValid is true if the signature has the size and
+	// structure expected for the key type.
+	SignatureFormatValid bool
+	// SignatureValid is true if the signature is valid for the message.
+	SignatureValid bool
+	// Err is the error of the first failing step, if any.
+	Err error
+}
+
+// Valid reports whether the signature was successfully verified.
+func (r VerifyResult) Valid() bool {
+	return r.SignatureValid
+}
+
+// VerifyDiagnose verifies sig over msg with key like Verifier.Verify, but
+// reports which step of the verification failed: an unsupported key type, an
+// invalid key, a malformed signature, or a signature that does not match the
+// message. The steps before the cryptographic check only depend on public
+// sizes and structure, so the result does not reveal more than a length
+// check would.
+func VerifyDiagnose(key *data.PublicKey, msg, sig []byte) VerifyResult {
+	var r VerifyResult
+	if _, ok := VerifierMap.Load(key.Type); !ok {
+		r.Err = ErrInvalidKey
+		return r
+	}
+	r.KeyTypeSupported = true
+
+	verifier, err := GetVerifier(key)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	r.KeyValid = true
+
+	if c, ok := verifier.(signatureFormatChecker); ok {
+		if err := c.checkSignatureFormat(sig); err != nil {
+			r.Err = err
+			return r
+		}
+	}
+	r.SignatureFormatValid = true
+
+	if err := verifier.Verify(msg, sig); err != nil {
+		r.Err = err
+		return r
+	}
+	r.SignatureValid = true
+	return r
+}
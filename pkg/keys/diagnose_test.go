@@ -0,0 +1,61 @@
+package keys
+
+import (
+	"encoding/json"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type DiagnoseSuite struct{}
+
+var _ = Suite(&DiagnoseSuite{})
+
+func (DiagnoseSuite) TestVerifyDiagnose(c *C) {
+	ed25519Key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ecdsaKey, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsaKey, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	for _, signer := range []Signer{ed25519Key, ecdsaKey, rsaKey} {
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+		pub := signer.PublicData()
+
+		r := VerifyDiagnose(pub, msg, sig)
+		c.Assert(r, DeepEquals, VerifyResult{
+			KeyTypeSupported:     true,
+			KeyValid:             true,
+			SignatureFormatValid: true,
+			SignatureValid:       true,
+		})
+		c.Assert(r.Valid(), Equals, true)
+
+		// Wrong message: only the cryptographic check fails.
+		r = VerifyDiagnose(pub, []byte("bar"), sig)
+		c.Assert(r.SignatureFormatValid, Equals, true)
+		c.Assert(r.SignatureValid, Equals, false)
+		c.Assert(r.Err, NotNil)
+
+		// Truncated signature: the format check fails.
+		r = VerifyDiagnose(pub, msg, sig[:len(sig)-1])
+		c.Assert(r.KeyValid, Equals, true)
+		c.Assert(r.SignatureFormatValid, Equals, false)
+		c.Assert(r.SignatureValid, Equals, false)
+		c.Assert(r.Err, NotNil)
+	}
+}
+
+func (DiagnoseSuite) TestVerifyDiagnoseBadKey(c *C) {
+	r := VerifyDiagnose(&data.PublicKey{Type: "bogus"}, nil, nil)
+	c.Assert(r, DeepEquals, VerifyResult{Err: ErrInvalidKey})
+
+	value, _ := json.Marshal(map[string]string{"public": "00"})
+	r = VerifyDiagnose(&data.PublicKey{Type: data.KeyTypeEd25519, Value: value}, nil, nil)
+	c.Assert(r.KeyTypeSupported, Equals, true)
+	c.Assert(r.KeyValid, Equals, false)
+	c.Assert(r.Err, NotNil)
+}
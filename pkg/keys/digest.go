@@ -0,0 +1,129 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+// ErrDigestMismatch is returned by VerifyWithExpectedDigest when the message
+// does not have the expected digest.
+var ErrDigestMismatch = errors.New("tuf: message digest mismatch")
+
+// ErrPrehashNotSupported is returned by SignDigest and VerifyMultihash for
+// keys that sign the full message, such as pure Ed25519 keys.
+var ErrPrehashNotSupported = errors.New("tuf: signer does not sign message digests")
+
+// SignDigest returns the signature of a message given its digest, computed
+// with the hash function of the key scheme of s. DigestSigners, such as
+// Ed25519ph signers, and ECDSA signers are supported.
+//
+// Pure Ed25519 signers fail with ErrPrehashNotSupported: they sign the
+// message itself, so a signature of the digest would not verify against the
+// message. Wrap them with NewEd25519phSigner to sign digests instead.
+func SignDigest(s Signer, digest []byte) ([]byte, error) {
+	switch k := s.(type) {
+	case DigestSigner:
+		return k.SignDigest(digest)
+	case *ecdsaSigner:
+		if len(digest) != k.hash.Size() {
+			return nil, fmt.Errorf("%w: %v digest must be %d bytes, got %d", ErrInvalidArgument, k.hash, k.hash.Size(), len(digest))
+		}
+		return k.signDigest(digest)
+	case *ed25519Signer:
+		return nil, fmt.Errorf("%w: pure ed25519 signs the full message, use an ed25519ph signer", ErrPrehashNotSupported)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPrehashNotSupported, signerAlgorithm(s))
+}
+
+// ErrPrehashedMessage is returned by Sign with WithAssertNotPrehashed when
+// the message looks like a digest.
+var ErrPrehashedMessage = errors.New("tuf: message has the size of a digest")
+
+// A SignOption configures Sign.
+type SignOption func(*signOptions)
+
+type signOptions struct {
+	assertNotPrehashed bool
+}
+
+// WithAssertNotPrehashed asserts that the message given to Sign is the
+// message itself rather than a digest of it, which Sign rejects with
+// ErrPrehashedMessage when the message has the size of a SHA-256, SHA-384 or
+// SHA-512 digest. It guards against passing a digest to a signer that hashes
+// the message itself, or to a pure Ed25519 signer, which does not hash it:
+// either way the signature would only verify against the digest. Use
+// SignDigest, with an Ed25519ph signer for Ed25519 keys, to sign digests.
+//
+// The check is a heuristic based on the size of the message, only meant for
+// callers which never sign messages of these sizes.
+func WithAssertNotPrehashed() SignOption {
+	return func(o *signOptions) {
+		o.assertNotPrehashed = true
+	}
+}
+
+// Sign signs msg with s, like s.SignMessage, after applying the checks
+// requested by opts.
+func Sign(s Signer, msg []byte, opts ...SignOption) ([]byte, error) {
+	var o signOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.assertNotPrehashed {
+		switch len(msg) {
+		case crypto.SHA256.Size(), crypto.SHA384.Size(), crypto.SHA512.Size():
+			return nil, fmt.Errorf("%w: %d bytes", ErrPrehashedMessage, len(msg))
+		}
+	}
+	return s.SignMessage(msg)
+}
+
+// messageDigester is implemented by signers that can report the digest of a
+// message that they sign. Signers that do not hash the message before
+// signing it, such as ed25519, return the message itself.
+type messageDigester interface {
+	digest(msg []byte) ([]byte, error)
+}
+
+// SignAndDigest signs msg with s, and also returns the digest that was
+// actually signed, so that it can be recorded, for example in audit logs,
+// without hashing the message again. For signers that do not pre-hash the
+// message, such as ed25519, the digest is the message itself.
+func SignAndDigest(s Signer, msg []byte) (sig []byte, digest []byte, err error) {
+	digest, err = signerDigest(s, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err = s.SignMessage(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, digest, nil
+}
+
+func signerDigest(s Signer, msg []byte) ([]byte, error) {
+	d, ok := s.(messageDigester)
+	if !ok {
+		return nil, errors.New("tuf: signer does not support reporting its digest")
+	}
+	return d.digest(msg)
+}
+
+// VerifyWithExpectedDigest checks that the hash digest of msg is
+// expectedDigest, typically taken from a manifest, and only then verifies sig
+// over msg with v. Corrupted content thus fails with ErrDigestMismatch,
+// distinctly from a signature failure, and no signature is verified over
+// content known to be wrong.
+func VerifyWithExpectedDigest(v Verifier, msg, sig []byte, hash crypto.Hash, expectedDigest []byte) error {
+	if !hash.Available() {
+		return fmt.Errorf("%w: unavailable hash function %v", ErrInvalidArgument, hash)
+	}
+	h := hash.New()
+	h.Write(msg)
+	if subtle.ConstantTimeCompare(h.Sum(nil), expectedDigest) != 1 {
+		return ErrDigestMismatch
+	}
+	return v.Verify(msg, sig)
+}
@@ -0,0 +1,139 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type DigestSuite struct{}
+
+var _ = Suite(&DigestSuite{})
+
+func (DigestSuite) TestSignAndDigestEcdsa(c *C) {
+	msg := []byte("foo")
+	p256, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	sig, digest, err := SignAndDigest(p256, msg)
+	c.Assert(err, IsNil)
+	expected := sha256.Sum256(msg)
+	c.Assert(digest, DeepEquals, expected[:])
+	c.Assert(verifyWith(c, p256, msg, sig), IsNil)
+
+	p384, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	c.Assert(err, IsNil)
+	_, digest, err = SignAndDigest(p384, msg)
+	c.Assert(err, IsNil)
+	expected384 := sha512.Sum384(msg)
+	c.Assert(digest, DeepEquals, expected384[:])
+}
+
+func (DigestSuite) TestSignAndDigestEd25519(c *C) {
+	msg := []byte("foo")
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	sig, digest, err := SignAndDigest(signer, msg)
+	c.Assert(err, IsNil)
+	c.Assert(digest, DeepEquals, msg)
+	c.Assert(verifyWith(c, signer, msg, sig), IsNil)
+}
+
+func (DigestSuite) TestSignAndDigestWrappedSigner(c *C) {
+	msg := []byte("foo")
+	signer, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	_, digest, err := SignAndDigest(InstrumentSigner(signer, &recordingObserver{}), msg)
+	c.Assert(err, IsNil)
+	expected := sha256.Sum256(msg)
+	c.Assert(digest, DeepEquals, expected[:])
+}
+
+func verifyWith(c *C, s Signer, msg, sig []byte) error {
+	verifier, err := GetVerifier(s.PublicData())
+	c.Assert(err, IsNil)
+	return verifier.Verify(msg, sig)
+}
+
+func (DigestSuite) TestVerifyWithExpectedDigest(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	expected := sha256.Sum256(msg)
+
+	c.Assert(VerifyWithExpectedDigest(verifier, msg, sig, crypto.SHA256, expected[:]), IsNil)
+
+	// Corrupted message.
+	err = VerifyWithExpectedDigest(verifier, []byte("fop"), sig, crypto.SHA256, expected[:])
+	c.Assert(err, Equals, ErrDigestMismatch)
+
+	// Corrupted signature.
+	corrupted := append([]byte{}, sig...)
+	corrupted[0] ^= 1
+	err = VerifyWithExpectedDigest(verifier, msg, corrupted, crypto.SHA256, expected[:])
+	c.Assert(err, NotNil)
+	c.Assert(err, Not(Equals), ErrDigestMismatch)
+
+	// Digest of another hash function.
+	err = VerifyWithExpectedDigest(verifier, msg, sig, crypto.SHA512, expected[:])
+	c.Assert(err, Equals, ErrDigestMismatch)
+
+	err = VerifyWithExpectedDigest(verifier, msg, sig, crypto.Hash(0), expected[:])
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+}
+
+func (DigestSuite) TestSignDigest(c *C) {
+	msg := []byte("foo")
+	digest := sha512.Sum512(msg)
+
+	// Pure ed25519 refuses digests, even SHA-512 ones.
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	_, err = SignDigest(ed, digest[:])
+	c.Assert(errors.Is(err, ErrPrehashNotSupported), Equals, true)
+	_, err = SignDigest(ed, msg)
+	c.Assert(errors.Is(err, ErrPrehashNotSupported), Equals, true)
+
+	ec, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	sha := sha256.Sum256(msg)
+	sig, err := SignDigest(ec, sha[:])
+	c.Assert(err, IsNil)
+	c.Assert(verifyWith(c, ec, msg, sig), IsNil)
+	_, err = SignDigest(ec, digest[:])
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	_, err = SignDigest(rsa, sha[:])
+	c.Assert(errors.Is(err, ErrPrehashNotSupported), Equals, true)
+}
+
+func (DigestSuite) TestSignAssertNotPrehashed(c *C) {
+	msg := []byte("foo")
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+
+	sig, err := Sign(ed, msg, WithAssertNotPrehashed())
+	c.Assert(err, IsNil)
+	c.Assert(verifyWith(c, ed, msg, sig), IsNil)
+
+	sha256Digest := sha256.Sum256(msg)
+	sha512Digest := sha512.Sum512(msg)
+	for _, digest := range [][]byte{sha256Digest[:], sha512Digest[:]} {
+		_, err = Sign(ed, digest, WithAssertNotPrehashed())
+		c.Assert(errors.Is(err, ErrPrehashedMessage), Equals, true)
+
+		// Without the option, the digest is signed as a message.
+		sig, err = Sign(ed, digest)
+		c.Assert(err, IsNil)
+		c.Assert(verifyWith(c, ed, digest, sig), IsNil)
+	}
+}
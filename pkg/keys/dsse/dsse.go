@@ -0,0 +1,48 @@
+// Package dsse signs and verifies payloads following the Dead Simple Signing
+// Envelope protocol (https://github.com/secure-systems-lab/dsse), as used by
+// in-toto attestations. Signatures are computed over the Pre-Authentication
+// Encoding (PAE) of the payload and its type, so that a signature for one
+// payload type cannot be replayed for another.
+package dsse
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+// ErrInvalid is returned by VerifyDSSE when the signature does not match.
+var ErrInvalid = errors.New("tuf: dsse signature verification failed")
+
+// PAE returns the DSSE v1 Pre-Authentication Encoding of payload:
+//
+//	"DSSEv1" SP LEN(type) SP type SP LEN(payload) SP payload
+//
+// where LEN is the length in bytes as an ASCII decimal integer.
+func PAE(payloadType string, payload []byte) []byte {
+	typeLen := strconv.Itoa(len(payloadType))
+	payloadLen := strconv.Itoa(len(payload))
+	b := make([]byte, 0, 10+len(typeLen)+len(payloadType)+len(payloadLen)+len(payload))
+	b = append(b, "DSSEv1 "...)
+	b = append(b, typeLen...)
+	b = append(b, ' ')
+	b = append(b, payloadType...)
+	b = append(b, ' ')
+	b = append(b, payloadLen...)
+	b = append(b, ' ')
+	return append(b, payload...)
+}
+
+// SignDSSE signs the PAE of payload with s.
+func SignDSSE(s keys.Signer, payloadType string, payload []byte) ([]byte, error) {
+	return s.SignMessage(PAE(payloadType, payload))
+}
+
+// VerifyDSSE verifies that sig is a signature of the PAE of payload by v.
+func VerifyDSSE(v keys.Verifier, payloadType string, payload, sig []byte) error {
+	if err := v.Verify(PAE(payloadType, payload), sig); err != nil {
+		return ErrInvalid
+	}
+	return nil
+}
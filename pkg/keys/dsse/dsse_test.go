@@ -0,0 +1,102 @@
+package dsse
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type DSSESuite struct{}
+
+var _ = Suite(&DSSESuite{})
+
+// The example of the DSSE protocol specification
+// (https://github.com/secure-systems-lab/dsse/blob/master/protocol.md), as
+// checked by the tests of the reference Go implementation: an ECDSA P-256
+// key, and a raw r||s signature of the PAE of vectorPayload with SHA-256 and
+// RFC 6979 nonces.
+const (
+	vectorPayloadType = "http://example.com/HelloWorld"
+	vectorPayload     = "hello world"
+
+	vectorX   = "46950820868899156662930047687818585632848591499744589407958293238635476079160"
+	vectorY   = "5640078356564379163099075877009565129882514886557779369047442380624545832820"
+	vectorD   = "97358161215184420915383655311931858321456579547487070936769975997791359926199"
+	vectorSig = "A3JqsQGtVsJ2O2xqrI5IcnXip5GToJ3F+FnZ+O88SjtR6rDAajabZKciJTfUiHqJPcIAriEGAHTVeCUjW2JIZA=="
+)
+
+func vectorInt(c *C, s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	c.Assert(ok, Equals, true)
+	return n
+}
+
+func (DSSESuite) TestPAE(c *C) {
+	// From the DSSE protocol specification.
+	c.Assert(string(PAE(vectorPayloadType, []byte(vectorPayload))), Equals,
+		"DSSEv1 29 http://example.com/HelloWorld 11 hello world")
+	c.Assert(string(PAE("", nil)), Equals, "DSSEv1 0  0 ")
+	// Lengths are in bytes, not characters.
+	c.Assert(string(PAE(vectorPayloadType, []byte("ಠ"))), Equals,
+		"DSSEv1 29 http://example.com/HelloWorld 3 ಠ")
+}
+
+func (DSSESuite) TestSpecVector(c *C) {
+	pub := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     vectorInt(c, vectorX),
+		Y:     vectorInt(c, vectorY),
+	}
+	priv, err := keys.EcdsaPrivateFromScalar(elliptic.P256(), vectorInt(c, vectorD).Bytes())
+	c.Assert(err, IsNil)
+	c.Assert(priv.PublicKey.Equal(pub), Equals, true)
+
+	pk, err := keys.ToPublicKey(pub, nil)
+	c.Assert(err, IsNil)
+	verifier, err := keys.GetVerifier(pk)
+	c.Assert(err, IsNil)
+
+	raw, err := base64.StdEncoding.DecodeString(vectorSig)
+	c.Assert(err, IsNil)
+	sig, err := keys.EcdsaRawToDER(raw, 32)
+	c.Assert(err, IsNil)
+	c.Assert(VerifyDSSE(verifier, vectorPayloadType, []byte(vectorPayload), sig), IsNil)
+
+	// The signature is over the PAE, not the payload.
+	c.Assert(verifier.Verify([]byte(vectorPayload), sig), NotNil)
+	c.Assert(VerifyDSSE(verifier, "http://example.com/Hello", []byte(vectorPayload), sig), Equals, ErrInvalid)
+}
+
+func (DSSESuite) TestSignVerify(c *C) {
+	for _, gen := range []func() (keys.Signer, error){
+		func() (keys.Signer, error) { return keys.GenerateEd25519Key() },
+		func() (keys.Signer, error) { return keys.GenerateEcdsaKey() },
+	} {
+		signer, err := gen()
+		c.Assert(err, IsNil)
+		verifier, err := keys.GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+
+		payload := []byte(`{"_type":"https://in-toto.io/Statement/v0.1"}`)
+		sig, err := SignDSSE(signer, "application/vnd.in-toto+json", payload)
+		c.Assert(err, IsNil)
+		c.Assert(VerifyDSSE(verifier, "application/vnd.in-toto+json", payload, sig), IsNil)
+
+		// The signature is bound to the payload type and the payload.
+		c.Assert(VerifyDSSE(verifier, "application/json", payload, sig), Equals, ErrInvalid)
+		c.Assert(VerifyDSSE(verifier, "application/vnd.in-toto+json", payload[1:], sig), Equals, ErrInvalid)
+
+		// A plain signature of the payload is not a DSSE signature.
+		plain, err := signer.SignMessage(payload)
+		c.Assert(err, IsNil)
+		c.Assert(VerifyDSSE(verifier, "application/vnd.in-toto+json", payload, plain), Equals, ErrInvalid)
+	}
+}
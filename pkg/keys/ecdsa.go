@@ -1,71 +1,707 @@
 package keys
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
-	"crypto/sha256"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
 	"encoding/asn1"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"io"
 	"math/big"
+	"strings"
+	"sync"
 
 	"github.com/theupdateframework/go-tuf/data"
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
 )
 
 func init() {
-	VerifierMap.Store(data.KeyTypeECDSA_SHA2_P256, NewEcdsaVerifier)
+	RegisterEcdsaKeyType(data.KeyTypeECDSA_SHA2_P256, data.KeySchemeECDSA_SHA2_P256, elliptic.P256(), crypto.SHA256)
+	RegisterEcdsaKeyType(data.KeyTypeECDSA_SHA2_P384, data.KeySchemeECDSA_SHA2_P384, elliptic.P384(), crypto.SHA384)
+	RegisterEcdsaKeyType(data.KeyTypeECDSA_SHA2_P521, data.KeySchemeECDSA_SHA2_P521, elliptic.P521(), crypto.SHA512)
+
+	// The curve of generic ECDSA keys is resolved when the key is
+	// unmarshalled, see resolveEcdsaParams.
+	VerifierMap.Store(data.KeyTypeECDSA, NewEcdsaVerifier)
+	SignerMap.Store(data.KeyTypeECDSA, NewEcdsaSigner)
+}
+
+// ecdsaAutoDetectKeyTypes lists, in order of preference, the key types tried
+// when detecting the curve of a generic ECDSA key without a scheme.
+var ecdsaAutoDetectKeyTypes = []string{
+	data.KeyTypeECDSA_SHA2_P256,
+	data.KeyTypeECDSA_SHA2_P384,
+	data.KeyTypeECDSA_SHA2_P521,
+}
+
+// ecdsaParams describes the curve and hash function backing an ECDSA key
+// type.
+type ecdsaParams struct {
+	scheme string
+	curve  elliptic.Curve
+	hash   crypto.Hash
+}
+
+// ecdsaKeyTypes stores mapping between ECDSA key type strings and their
+// *ecdsaParams.
+var ecdsaKeyTypes sync.Map
+
+// RegisterEcdsaKeyType registers an ECDSA key type backed by the given curve
+// and hash function, so that keys of that type can be used through
+// GetVerifier and GetSigner. It panics if the hash function is not linked
+// into the binary, as every use of the key type would fail.
+func RegisterEcdsaKeyType(keyType, scheme string, curve elliptic.Curve, hash crypto.Hash) {
+	if !hash.Available() {
+		panic(fmt.Sprintf("tuf: hash function %v of ecdsa key type %q is not available", hash, keyType))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	ecdsaKeyTypes.Store(keyType, &ecdsaParams{
+		scheme: scheme,
+		curve:  curve,
+		hash:   hash,
+	})
+	VerifierMap.Store(keyType, NewEcdsaVerifier)
+	SignerMap.Store(keyType, NewEcdsaSigner)
+	keySchemes.Store(keyType, []string{scheme})
+}
+
+func getEcdsaParams(keyType string) (*ecdsaParams, error) {
+	p, ok := ecdsaKeyTypes.Load(keyType)
+	if !ok {
+		return nil, fmt.Errorf("tuf: unsupported ecdsa key type %q", keyType)
+	}
+	return p.(*ecdsaParams), nil
+}
+
+// resolveEcdsaParams returns the parameters for an ECDSA key of the given
+// type and scheme. For generic ECDSA keys the curve is taken from the scheme
+// or, if there is none, detected from the size of the encoded point.
+//
+// The declared type and scheme must agree with each other and with the size
+// of the point, otherwise ErrKeyMismatch is returned.
+func resolveEcdsaParams(keyType, scheme string, point []byte) (*ecdsaParams, error) {
+	if keyType != data.KeyTypeECDSA {
+		params, err := getEcdsaParams(keyType)
+		if err != nil {
+			return nil, err
+		}
+		if scheme != "" && scheme != params.scheme {
+			return nil, fmt.Errorf("%w: scheme %q for key type %q", ErrKeyMismatch, scheme, keyType)
+		}
+		return params, checkEcdsaPointSize(params, point)
+	}
+	if scheme != "" {
+		params, err := getEcdsaParams(scheme)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedCurve, scheme)
+		}
+		return params, checkEcdsaPointSize(params, point)
+	}
+	for _, t := range ecdsaAutoDetectKeyTypes {
+		params, err := getEcdsaParams(t)
+		if err != nil {
+			continue
+		}
+		if checkEcdsaPointSize(params, point) != nil {
+			continue
+		}
+		if x, _ := unmarshalEcdsaPoint(params.curve, point); x != nil {
+			return params, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unable to detect the curve of ecdsa key", ErrUnsupportedCurve)
+}
+
+// checkEcdsaPointSize checks that point has the size of an uncompressed or
+// compressed SEC1 point on the curve of params. The on-curve check alone
+// does not reject every point of another curve.
+func checkEcdsaPointSize(params *ecdsaParams, point []byte) error {
+	size := curveByteSize(params.curve)
+	if len(point) != 1+2*size && len(point) != 1+size {
+		return fmt.Errorf("%w: %d-byte point for %s", ErrKeyMismatch, len(point), params.curve.Params().Name)
+	}
+	return nil
 }
 
 func NewEcdsaVerifier() Verifier {
-	return &p256Verifier{}
+	return &ecdsaVerifier{}
+}
+
+func NewEcdsaSigner() Signer {
+	return &ecdsaSigner{}
 }
 
 type ecdsaSignature struct {
 	R, S *big.Int
 }
 
-type p256Verifier struct {
+type ecdsaVerifier struct {
 	PublicKey data.HexBytes `json:"public"`
+	params    *ecdsaParams
 	key       *data.PublicKey
+
+	// pem holds the public value of keys given as a PEM encoded PKIX public
+	// key, from which PublicKey is derived.
+	pem string
 }
 
-func (p *p256Verifier) Public() string {
+func (p *ecdsaVerifier) Public() string {
 	return p.PublicKey.String()
 }
 
-func (p *p256Verifier) Verify(msg, sigBytes []byte) error {
-	x, y := elliptic.Unmarshal(elliptic.P256(), p.PublicKey)
-	k := &ecdsa.PublicKey{
-		Curve: elliptic.P256(),
-		X:     x,
-		Y:     y,
+func (p *ecdsaVerifier) Verify(msg, sigBytes []byte) error {
+	return p.verifyWithOptions(msg, sigBytes, &VerifyOptions{})
+}
+
+func (p *ecdsaVerifier) verifyWithOptions(msg, sigBytes []byte, opts *VerifyOptions) error {
+	hash := p.params.hash
+	if opts.Hash != 0 {
+		if !opts.Hash.Available() {
+			return fmt.Errorf("%w: unavailable hash function %v", ErrInvalidArgument, opts.Hash)
+		}
+		hash = opts.Hash
+	}
+	if opts.ExpectedHash != 0 {
+		if hash != opts.ExpectedHash {
+			return fmt.Errorf("%w: verifying with %v, expected %v", ErrHashMismatch, hash, opts.ExpectedHash)
+		}
+		if hash.Size() < curveByteSize(p.params.curve) {
+			return fmt.Errorf("%w: %v digests are too short for %s", ErrHashMismatch, hash, p.params.curve.Params().Name)
+		}
+	}
+	h := hash.New()
+	h.Write(msg)
+	return p.verifyDigest(h.Sum(nil), sigBytes, opts)
+}
+
+func (p *ecdsaVerifier) VerifyReader(r io.Reader, sigBytes []byte) error {
+	h := p.params.hash.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	return p.verifyDigest(h.Sum(nil), sigBytes, &VerifyOptions{})
+}
+
+func (p *ecdsaVerifier) verifyDigest(digest, sigBytes []byte, opts *VerifyOptions) error {
+	// Guard against verifiers built with misconfigured parameters, which
+	// would otherwise accept degenerate signatures or panic.
+	if curveByteSize(p.params.curve) <= 0 {
+		return fmt.Errorf("%w: invalid ecdsa key size", ErrInvalid)
+	}
+	if len(sigBytes) == 0 {
+		return fmt.Errorf("%w: empty ecdsa signature", ErrInvalid)
+	}
+	x, y := unmarshalEcdsaPoint(p.params.curve, p.PublicKey)
+	if x == nil {
+		return errInvalidEcdsaPoint
+	}
+	sig := getEcdsaSignature()
+	defer putEcdsaSignature(sig)
+	pub := &ecdsa.PublicKey{Curve: p.params.curve, X: x, Y: y}
+	if err := p.parseSignature(sig, sigBytes, opts); err != nil {
+		if opts.LenientSignatureLength && len(sigBytes) < 2*curveByteSize(p.params.curve) {
+			return verifyEcdsaUnpaddedDigest(pub, digest, sig, sigBytes)
+		}
+		return err
+	}
+	return verifyEcdsaDigest(pub, digest, sig)
+}
+
+// verifyEcdsaUnpaddedDigest verifies a raw r||s signature whose values were
+// not padded to the curve size, trying each split of sigBytes into r and s
+// values that fit the curve size.
+func verifyEcdsaUnpaddedDigest(pub *ecdsa.PublicKey, digest []byte, sig *ecdsaSignature, sigBytes []byte) error {
+	size := curveByteSize(pub.Curve)
+	for n := len(sigBytes) - size; n <= size; n++ {
+		if n < 1 || n >= len(sigBytes) {
+			continue
+		}
+		sig.R.SetBytes(sigBytes[:n])
+		sig.S.SetBytes(sigBytes[n:])
+		if verifyEcdsaDigest(pub, digest, sig) == nil {
+			return nil
+		}
 	}
+	return errors.New("tuf: ecdsa signature verification failed")
+}
+
+// parseSignature parses into sig an ASN.1 DER signature or, if
+// opts.AutoSignatureFormat is set and the signature is twice the curve size,
+// a raw r||s signature.
+func (p *ecdsaVerifier) parseSignature(sig *ecdsaSignature, sigBytes []byte, opts *VerifyOptions) error {
+	size := curveByteSize(p.params.curve)
+	if opts.AutoSignatureFormat && len(sigBytes) == 2*size {
+		sig.R.SetBytes(sigBytes[:size])
+		sig.S.SetBytes(sigBytes[size:])
+		return nil
+	}
+	return parseEcdsaDERSignature(sig, sigBytes)
+}
 
-	var sig ecdsaSignature
-	if _, err := asn1.Unmarshal(sigBytes, &sig); err != nil {
+// VerifyEcdsaPoint verifies an ASN.1 DER signature over msg using an ECDSA
+// public key given as a raw SEC1 point, in either compressed or uncompressed
+// form, on the curve of the registered ECDSA key type keyType.
+func VerifyEcdsaPoint(keyType string, point, msg, sigBytes []byte) error {
+	params, err := getEcdsaParams(keyType)
+	if err != nil {
 		return err
 	}
+	x, y := unmarshalEcdsaPoint(params.curve, point)
+	if x == nil {
+		return errInvalidEcdsaPoint
+	}
+	sig := getEcdsaSignature()
+	defer putEcdsaSignature(sig)
+	if err := parseEcdsaDERSignature(sig, sigBytes); err != nil {
+		return err
+	}
+	h := params.hash.New()
+	h.Write(msg)
+	return verifyEcdsaDigest(&ecdsa.PublicKey{Curve: params.curve, X: x, Y: y}, h.Sum(nil), sig)
+}
+
+// VerifyEcdsaHash verifies a raw r||s signature over an already computed
+// hash, for callers that hold the hash of the message rather than the message.
+// keySize is the size in bytes of each of r and s, and must be the byte size
+// of the curve of pub. As ecdsa.Verify truncates the hash to the size of the
+// curve order, any non-empty hash is accepted.
+func VerifyEcdsaHash(pub *ecdsa.PublicKey, hash, signature []byte, keySize int) error {
+	if pub == nil || pub.Curve == nil || pub.X == nil || pub.Y == nil {
+		return fmt.Errorf("%w: missing ecdsa public key", ErrInvalidArgument)
+	}
+	if keySize <= 0 || keySize != curveByteSize(pub.Curve) {
+		return fmt.Errorf("%w: ecdsa key size %d does not match the curve", ErrInvalidArgument, keySize)
+	}
+	if len(hash) == 0 {
+		return fmt.Errorf("%w: empty hash", ErrInvalidArgument)
+	}
+	if len(signature) != 2*keySize {
+		return fmt.Errorf("%w: raw ecdsa signature is %d bytes, want %d", ErrInvalid, len(signature), 2*keySize)
+	}
+	sig := getEcdsaSignature()
+	defer putEcdsaSignature(sig)
+	sig.R.SetBytes(signature[:keySize])
+	sig.S.SetBytes(signature[keySize:])
+	return verifyEcdsaDigest(pub, hash, sig)
+}
+
+// ecdsaSignaturePool holds preallocated signatures, to avoid allocating the
+// r and s integers on every verification. A signature is owned by a single
+// verification between getEcdsaSignature and putEcdsaSignature, and
+// ecdsa.Verify does not retain its arguments, so pooled values are never
+// shared between goroutines.
+var ecdsaSignaturePool = sync.Pool{
+	New: func() interface{} {
+		return &ecdsaSignature{R: new(big.Int), S: new(big.Int)}
+	},
+}
+
+func getEcdsaSignature() *ecdsaSignature {
+	return ecdsaSignaturePool.Get().(*ecdsaSignature)
+}
+
+func putEcdsaSignature(sig *ecdsaSignature) {
+	sig.R.SetInt64(0)
+	sig.S.SetInt64(0)
+	ecdsaSignaturePool.Put(sig)
+}
 
-	hash := sha256.Sum256(msg)
+// parseEcdsaDERSignature parses an ASN.1 DER signature into sig. Like
+// asn1.Unmarshal, it ignores any data following the signature.
+func parseEcdsaDERSignature(sig *ecdsaSignature, sigBytes []byte) error {
+	input := cryptobyte.String(sigBytes)
+	var inner cryptobyte.String
+	if !input.ReadASN1(&inner, cryptobyte_asn1.SEQUENCE) ||
+		!inner.ReadASN1Integer(sig.R) ||
+		!inner.ReadASN1Integer(sig.S) ||
+		!inner.Empty() {
+		return errors.New("tuf: invalid ecdsa signature encoding")
+	}
+	return nil
+}
 
-	if !ecdsa.Verify(k, hash[:], sig.R, sig.S) {
+func verifyEcdsaDigest(k *ecdsa.PublicKey, digest []byte, sig *ecdsaSignature) error {
+	if !ecdsa.Verify(k, digest, sig.R, sig.S) {
 		return errors.New("tuf: ecdsa signature verification failed")
 	}
 	return nil
 }
 
-func (p *p256Verifier) MarshalPublicKey() *data.PublicKey {
+func (p *ecdsaVerifier) checkSignatureFormat(sigBytes []byte) error {
+	return checkEcdsaSignatureFormat(p.params.curve, sigBytes, &VerifyOptions{})
+}
+
+// checkEcdsaSignatureFormat checks that sigBytes is an ASN.1 DER signature,
+// or with opts.AutoSignatureFormat a raw r||s signature, whose values are in
+// the range allowed by curve. A nil curve only checks the encoding.
+func checkEcdsaSignatureFormat(curve elliptic.Curve, sigBytes []byte, opts *VerifyOptions) error {
+	sig := ecdsaSignature{R: new(big.Int), S: new(big.Int)}
+	if curve != nil && opts.AutoSignatureFormat && len(sigBytes) == 2*curveByteSize(curve) {
+		size := curveByteSize(curve)
+		sig.R.SetBytes(sigBytes[:size])
+		sig.S.SetBytes(sigBytes[size:])
+	} else {
+		rest, err := asn1.Unmarshal(sigBytes, &sig)
+		if err != nil {
+			return err
+		}
+		if len(rest) != 0 {
+			return errors.New("tuf: trailing data after ecdsa signature")
+		}
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return errors.New("tuf: ecdsa signature values out of range")
+	}
+	if curve == nil {
+		return nil
+	}
+	n := curve.Params().N
+	if sig.R.Cmp(n) >= 0 || sig.S.Cmp(n) >= 0 {
+		return errors.New("tuf: ecdsa signature values out of range")
+	}
+	return nil
+}
+
+func (p *ecdsaVerifier) normalizedValue() (json.RawMessage, error) {
+	x, y := unmarshalEcdsaPoint(p.params.curve, p.PublicKey)
+	if x == nil {
+		return nil, errInvalidEcdsaPoint
+	}
+	return json.Marshal(ecdsaVerifier{PublicKey: elliptic.Marshal(p.params.curve, x, y)})
+}
+
+func (p *ecdsaVerifier) MarshalPublicKey() *data.PublicKey {
 	return p.key
 }
 
-func (p *p256Verifier) UnmarshalPublicKey(key *data.PublicKey) error {
-	if err := json.Unmarshal(key.Value, p); err != nil {
+// UnmarshalPublicKey only accepts uncompressed points: key IDs are computed
+// over the encoded point, so accepting both encodings would give one key two
+// IDs, which could then be counted twice toward a signature threshold. Use
+// NormalizeKey to migrate keys with compressed points.
+func (p *ecdsaVerifier) UnmarshalPublicKey(key *data.PublicKey) error {
+	return p.unmarshalPublicKey(key, false)
+}
+
+func (p *ecdsaVerifier) unmarshalPublicKey(key *data.PublicKey, allowCompressed bool) error {
+	pemCurve, err := p.unmarshalValue(key.Value)
+	if err != nil {
+		return err
+	}
+	if len(p.PublicKey) == 0 {
+		return ErrMissingPublicKey
+	}
+	params, err := resolveEcdsaParams(key.Type, key.Scheme, p.PublicKey)
+	if err != nil {
 		return err
 	}
-	x, _ := elliptic.Unmarshal(elliptic.P256(), p.PublicKey)
+	if pemCurve != nil && pemCurve != params.curve {
+		return fmt.Errorf("%w: %s PEM public key for key type %q", ErrKeyMismatch, pemCurve.Params().Name, key.Type)
+	}
+	if !allowCompressed && p.PublicKey[0] != 4 {
+		return errCompressedEcdsaPoint
+	}
+	x, _ := unmarshalEcdsaPoint(params.curve, p.PublicKey)
 	if x == nil {
-		return errors.New("tuf: invalid ecdsa public key point")
+		return errInvalidEcdsaPoint
 	}
+	p.params = params
 	p.key = key
 	return nil
 }
+
+// unmarshalValue parses the public value of an ECDSA key object: a hex SEC1
+// point or, as written by python's securesystemslib, a PEM encoded PKIX
+// public key. The latter is kept as is, so that the key ID matches the one
+// python-tuf computes, and its curve is returned.
+func (p *ecdsaVerifier) unmarshalValue(value json.RawMessage) (elliptic.Curve, error) {
+	var pemValue struct {
+		Public string `json:"public"`
+	}
+	if err := json.Unmarshal(value, &pemValue); err != nil || !strings.HasPrefix(strings.TrimSpace(pemValue.Public), "-----BEGIN") {
+		return nil, json.Unmarshal(value, p)
+	}
+	block, _ := pem.Decode([]byte(pemValue.Public))
+	if block == nil {
+		return nil, fmt.Errorf("%w: invalid PEM public key", ErrMalformedKey)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedKey, err)
+	}
+	k, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: PEM public key is a %T", ErrKeyMismatch, pub)
+	}
+	p.PublicKey = elliptic.Marshal(k.Curve, k.X, k.Y)
+	p.pem = pemValue.Public
+	return k.Curve, nil
+}
+
+type ecdsaPrivateKeyValue struct {
+	Public  data.HexBytes `json:"public"`
+	Private data.HexBytes `json:"private"`
+}
+
+type ecdsaSigner struct {
+	*ecdsa.PrivateKey
+
+	hash          crypto.Hash
+	keyType       string
+	keyScheme     string
+	keyAlgorithms []string
+
+	// rand is the random source used for signing, crypto/rand.Reader if
+	// nil.
+	rand io.Reader
+}
+
+// GenerateEcdsaKey generates a new NIST P-256 ECDSA key.
+func GenerateEcdsaKey() (*ecdsaSigner, error) {
+	return GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P256)
+}
+
+// GenerateEcdsaKeyWithType generates a new ECDSA key for a registered ECDSA
+// key type.
+func GenerateEcdsaKeyWithType(keyType string) (*ecdsaSigner, error) {
+	return generateEcdsaKey(keyType, rand.Reader)
+}
+
+func generateEcdsaKey(keyType string, r io.Reader) (*ecdsaSigner, error) {
+	params, err := getEcdsaParams(keyType)
+	if err != nil {
+		return nil, err
+	}
+	privkey, err := ecdsa.GenerateKey(params.curve, r)
+	if err != nil {
+		return nil, err
+	}
+	return &ecdsaSigner{
+		PrivateKey:    privkey,
+		hash:          params.hash,
+		keyType:       keyType,
+		keyScheme:     params.scheme,
+		keyAlgorithms: data.HashAlgorithms,
+	}, nil
+}
+
+func (s *ecdsaSigner) SignMessage(message []byte) ([]byte, error) {
+	digest, _ := s.digest(message)
+	return s.signDigest(digest)
+}
+
+func (s *ecdsaSigner) SignReader(r io.Reader) ([]byte, error) {
+	h := s.hash.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return s.signDigest(h.Sum(nil))
+}
+
+func (s *ecdsaSigner) signDigest(digest []byte) ([]byte, error) {
+	r := s.rand
+	if r == nil {
+		r = rand.Reader
+	}
+	if err := checkRandSource(r, curveByteSize(s.Curve)); err != nil {
+		return nil, err
+	}
+	return ecdsa.SignASN1(r, s.PrivateKey, digest)
+}
+
+func (s *ecdsaSigner) digest(message []byte) ([]byte, error) {
+	h := s.hash.New()
+	h.Write(message)
+	return h.Sum(nil), nil
+}
+
+// deterministic reports false, as the nonces are drawn from the random
+// source of the signer rather than derived as in RFC 6979.
+func (s *ecdsaSigner) deterministic() bool {
+	return false
+}
+
+func (s *ecdsaSigner) MarshalPrivateKey() (*data.PrivateKey, error) {
+	valueBytes, err := json.Marshal(ecdsaPrivateKeyValue{
+		Public:  elliptic.Marshal(s.Curve, s.X, s.Y),
+		Private: s.D.FillBytes(make([]byte, curveByteSize(s.Curve))),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &data.PrivateKey{
+		Type:       s.keyType,
+		Scheme:     s.keyScheme,
+		Algorithms: s.keyAlgorithms,
+		Value:      valueBytes,
+	}, nil
+}
+
+func (s *ecdsaSigner) UnmarshalPrivateKey(key *data.PrivateKey) error {
+	return s.unmarshalPrivateKeyWithOptions(key, &SignerOptions{})
+}
+
+func (s *ecdsaSigner) unmarshalPrivateKeyWithOptions(key *data.PrivateKey, opts *SignerOptions) error {
+	keyValue := &ecdsaPrivateKeyValue{}
+	if err := json.Unmarshal(key.Value, keyValue); err != nil {
+		return err
+	}
+	if len(keyValue.Public) == 0 {
+		return ErrMissingPublicKey
+	}
+	params, err := resolveEcdsaParams(key.Type, key.Scheme, keyValue.Public)
+	if err != nil {
+		return err
+	}
+
+	var privkey *ecdsa.PrivateKey
+	if opts.SkipConsistencyCheck {
+		// Trust the provided public key instead of deriving it, which
+		// saves a scalar multiplication.
+		d := new(big.Int).SetBytes(keyValue.Private)
+		if d.Sign() == 0 || d.Cmp(params.curve.Params().N) >= 0 {
+			return errors.New("tuf: invalid ecdsa private key")
+		}
+		if keyValue.Public[0] != 4 {
+			return errCompressedEcdsaPoint
+		}
+		x, y := unmarshalEcdsaPoint(params.curve, keyValue.Public)
+		if x == nil {
+			return errInvalidEcdsaPoint
+		}
+		privkey = &ecdsa.PrivateKey{D: d}
+		privkey.Curve, privkey.X, privkey.Y = params.curve, x, y
+	} else {
+		privkey, err = newEcdsaPrivateKey(params.curve, keyValue.Private)
+		if err != nil {
+			return err
+		}
+
+		// Make sure the provided public key matches the private key.
+		public := elliptic.Marshal(params.curve, privkey.X, privkey.Y)
+		if subtle.ConstantTimeCompare(public, keyValue.Public) != 1 {
+			return errors.New("tuf: ecdsa public and private keys do not match")
+		}
+	}
+
+	*s = ecdsaSigner{
+		PrivateKey:    privkey,
+		hash:          params.hash,
+		keyType:       key.Type,
+		keyScheme:     key.Scheme,
+		keyAlgorithms: key.Algorithms,
+		rand:          randSource(opts.Rand, opts.ValidateRand),
+	}
+	return nil
+}
+
+func (s *ecdsaSigner) PublicData() *data.PublicKey {
+	keyValBytes, _ := json.Marshal(ecdsaVerifier{PublicKey: elliptic.Marshal(s.Curve, s.X, s.Y)})
+	return &data.PublicKey{
+		Type:       s.keyType,
+		Scheme:     s.keyScheme,
+		Algorithms: s.keyAlgorithms,
+		Value:      keyValBytes,
+	}
+}
+
+// EcdsaPrivateScalar returns the private scalar D of priv as a fixed-width
+// big-endian byte string, as long as a scalar of the curve. D must be in
+// [1, N-1].
+func EcdsaPrivateScalar(priv *ecdsa.PrivateKey) ([]byte, error) {
+	if priv == nil || priv.Curve == nil || priv.D == nil {
+		return nil, fmt.Errorf("%w: incomplete ecdsa private key", ErrInvalidArgument)
+	}
+	size := curveByteSize(priv.Curve)
+	if priv.D.Sign() <= 0 || priv.D.Cmp(priv.Curve.Params().N) >= 0 {
+		return nil, errors.New("tuf: invalid ecdsa private key")
+	}
+	return priv.D.FillBytes(make([]byte, size)), nil
+}
+
+// EcdsaPrivateFromScalar returns the private key on curve with the
+// fixed-width big-endian private scalar, deriving its public point. The
+// scalar must be in [1, N-1].
+func EcdsaPrivateFromScalar(curve elliptic.Curve, scalar []byte) (*ecdsa.PrivateKey, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("%w: nil curve", ErrInvalidArgument)
+	}
+	if size := curveByteSize(curve); len(scalar) != size {
+		return nil, fmt.Errorf("%w: ecdsa scalar must be %d bytes, got %d", ErrInvalidArgument, size, len(scalar))
+	}
+	return newEcdsaPrivateKey(curve, scalar)
+}
+
+// newEcdsaPrivateKey checks that scalar is a valid private scalar of curve
+// and returns the corresponding private key.
+func newEcdsaPrivateKey(curve elliptic.Curve, scalar []byte) (*ecdsa.PrivateKey, error) {
+	d := new(big.Int).SetBytes(scalar)
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("tuf: invalid ecdsa private key")
+	}
+	privkey := &ecdsa.PrivateKey{D: d}
+	privkey.Curve = curve
+	privkey.X, privkey.Y = curve.ScalarBaseMult(scalar)
+	return privkey, nil
+}
+
+// CompressEcdsaPoint returns the SEC1 compressed encoding of pub.
+func CompressEcdsaPoint(pub *ecdsa.PublicKey) []byte {
+	return elliptic.MarshalCompressed(pub.Curve, pub.X, pub.Y)
+}
+
+// DecompressEcdsaPoint parses a SEC1 encoded point on curve, checking that it
+// is on the curve. Both compressed and uncompressed encodings are accepted.
+func DecompressEcdsaPoint(curve elliptic.Curve, data []byte) (*ecdsa.PublicKey, error) {
+	x, y := unmarshalEcdsaPoint(curve, data)
+	if x == nil {
+		return nil, errInvalidEcdsaPoint
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// errInvalidEcdsaPoint is returned for public keys that unmarshalEcdsaPoint
+// rejects.
+var errInvalidEcdsaPoint = fmt.Errorf("%w: invalid ecdsa public key point", ErrMalformedKey)
+
+// errCompressedEcdsaPoint is returned for key objects holding a compressed
+// point, which only the explicit point helpers accept.
+var errCompressedEcdsaPoint = fmt.Errorf("%w: compressed ecdsa public key point", ErrMalformedKey)
+
+// unmarshalEcdsaPoint parses a SEC1 encoded point in either uncompressed or
+// compressed form. It returns nil if the point is not on the curve or is the
+// point at infinity.
+//
+// Compressed points are only supported on curves of the form
+// y² = x³ - 3x + b, which includes the NIST curves.
+func unmarshalEcdsaPoint(curve elliptic.Curve, b []byte) (x, y *big.Int) {
+	if len(b) == 0 || b[0] == 4 {
+		x, y = elliptic.Unmarshal(curve, b)
+	} else {
+		x, y = elliptic.UnmarshalCompressed(curve, b)
+	}
+	if x == nil || !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	// The point at infinity has no affine coordinates, and is encoded as
+	// (0, 0) by crypto/elliptic. It is not on the curve, but reject it
+	// explicitly rather than rely on every curve implementation for that,
+	// as any signature verifies against it with some implementations.
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, nil
+	}
+	return x, y
+}
+
+// curveByteSize returns the size in bytes of a field element or scalar of
+// the curve.
+func curveByteSize(curve elliptic.Curve) int {
+	return (curve.Params().BitSize + 7) / 8
+}
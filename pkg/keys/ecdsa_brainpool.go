@@ -0,0 +1,19 @@
+//go:build brainpool
+// +build brainpool
+
+package keys
+
+import (
+	"crypto"
+
+	"github.com/ebfe/brainpool"
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// The brainpool curves are not part of the standard library, so support for
+// them is only compiled in with the "brainpool" build tag, which pulls in the
+// github.com/ebfe/brainpool dependency.
+func init() {
+	RegisterEcdsaKeyType(data.KeyTypeECDSA_SHA2_BrainpoolP256r1, data.KeySchemeECDSA_SHA2_BrainpoolP256r1, brainpool.P256r1(), crypto.SHA256)
+	RegisterEcdsaKeyType(data.KeyTypeECDSA_SHA2_BrainpoolP384r1, data.KeySchemeECDSA_SHA2_BrainpoolP384r1, brainpool.P384r1(), crypto.SHA384)
+}
@@ -0,0 +1,82 @@
+//go:build brainpool
+// +build brainpool
+
+package keys
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type BrainpoolSuite struct{}
+
+var _ = Suite(&BrainpoolSuite{})
+
+// Base points of the brainpool curves, as specified in RFC 5639.
+var brainpoolVectors = []struct {
+	keyType string
+	x, y    string
+}{
+	{
+		keyType: data.KeyTypeECDSA_SHA2_BrainpoolP256r1,
+		x:       "8bd2aeb9cb7e57cb2c4b482ffc81b7afb9de27e1e3bd23c23a4453bd9ace3262",
+		y:       "547ef835c3dac4fd97f8461a14611dc9c27745132ded8e545c1d54c72f046997",
+	},
+	{
+		keyType: data.KeyTypeECDSA_SHA2_BrainpoolP384r1,
+		x:       "1d1c64f068cf45ffa2a63a81b7c13f6b8847a3e77ef14fe3db7fcafe0cbd10e8e826e03436d646aaef87b2e247d4af1e",
+		y:       "8abe1d7520f9c2a45cb1eb8e95cfd55262b70b29feec5864e19c054ff99129280e4646217791811142820341263c5315",
+	},
+}
+
+func (BrainpoolSuite) TestSignVerify(c *C) {
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_BrainpoolP256r1, data.KeyTypeECDSA_SHA2_BrainpoolP384r1} {
+		signer, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		msg := []byte("foo")
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+		publicData := signer.PublicData()
+		c.Assert(publicData.Type, Equals, keyType)
+		pubKey, err := GetVerifier(publicData)
+		c.Assert(err, IsNil)
+		c.Assert(pubKey.Verify(msg, sig), IsNil)
+		c.Assert(pubKey.Verify([]byte("bar"), sig), NotNil)
+	}
+}
+
+func (BrainpoolSuite) TestKnownVectors(c *C) {
+	for _, v := range brainpoolVectors {
+		// A private scalar of one must produce the curve's base point.
+		params, err := getEcdsaParams(v.keyType)
+		c.Assert(err, IsNil)
+		size := curveByteSize(params.curve)
+		value, err := json.Marshal(ecdsaPrivateKeyValue{
+			Public:  mustHex(c, "04"+v.x+v.y),
+			Private: big.NewInt(1).FillBytes(make([]byte, size)),
+		})
+		c.Assert(err, IsNil)
+		signer, err := GetSigner(&data.PrivateKey{
+			Type:       v.keyType,
+			Scheme:     params.scheme,
+			Algorithms: data.HashAlgorithms,
+			Value:      value,
+		})
+		c.Assert(err, IsNil)
+
+		privKey, err := signer.MarshalPrivateKey()
+		c.Assert(err, IsNil)
+		roundtrip, err := GetSigner(privKey)
+		c.Assert(err, IsNil)
+
+		msg := []byte("foo")
+		sig, err := roundtrip.SignMessage(msg)
+		c.Assert(err, IsNil)
+		pubKey, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+		c.Assert(pubKey.Verify(msg, sig), IsNil)
+	}
+}
@@ -0,0 +1,158 @@
+package keys
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type EcdsaCAVPSuite struct{}
+
+var _ = Suite(&EcdsaCAVPSuite{})
+
+// cavpSigVerVector is a test case of the NIST CAVP ECDSA SigVer vectors.
+type cavpSigVerVector struct {
+	section        string
+	msg, qx, qy    string
+	r, s           string
+	valid          bool
+	expectedResult string
+}
+
+// cavpKeyTypes maps the sections of testdata/SigVer.rsp to the key type
+// using their curve and hash.
+var cavpKeyTypes = map[string]string{
+	"[P-256,SHA-256]": data.KeyTypeECDSA_SHA2_P256,
+	"[P-384,SHA-384]": data.KeyTypeECDSA_SHA2_P384,
+}
+
+func readCAVPSigVerVectors(c *C, path string) []cavpSigVerVector {
+	f, err := os.Open(path)
+	c.Assert(err, IsNil)
+	defer f.Close()
+
+	var (
+		vectors []cavpSigVerVector
+		section string
+		v       cavpSigVerVector
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			section = line
+			continue
+		}
+		parts := strings.SplitN(line, " = ", 2)
+		c.Assert(parts, HasLen, 2, Commentf("malformed line %q", line))
+		switch key, value := parts[0], parts[1]; key {
+		case "Msg":
+			v = cavpSigVerVector{section: section, msg: value}
+		case "Qx":
+			v.qx = value
+		case "Qy":
+			v.qy = value
+		case "R":
+			v.r = value
+		case "S":
+			v.s = value
+		case "Result":
+			v.valid = strings.HasPrefix(value, "P")
+			v.expectedResult = value
+			vectors = append(vectors, v)
+		default:
+			c.Fatalf("unexpected field %q", key)
+		}
+	}
+	c.Assert(scanner.Err(), IsNil)
+	return vectors
+}
+
+// cavpVerify verifies the signature of v with a verifier built from its key,
+// with the signature converted from the r and s values of the vector to the
+// DER form used by this package, and in raw form with AutoSignatureFormat.
+func cavpVerify(c *C, v cavpSigVerVector) (derErr, rawErr error) {
+	keyType := cavpKeyTypes[v.section]
+	params, err := getEcdsaParams(keyType)
+	c.Assert(err, IsNil)
+	size := curveByteSize(params.curve)
+
+	// The coordinates and signature values are zero padded to the curve
+	// size, as the vectors omit leading zeros.
+	pad := func(s string) []byte {
+		b, err := hex.DecodeString(strings.Repeat("0", 2*size-len(s)) + s)
+		c.Assert(err, IsNil)
+		return b
+	}
+	point := append([]byte{0x04}, pad(v.qx)...)
+	point = append(point, pad(v.qy)...)
+	raw := append(pad(v.r), pad(v.s)...)
+	msg, err := hex.DecodeString(v.msg)
+	c.Assert(err, IsNil)
+
+	value, err := json.Marshal(ecdsaVerifier{PublicKey: point})
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(&data.PublicKey{Type: keyType, Scheme: params.scheme, Value: value})
+	if err != nil {
+		// A public key changed to a point off the curve.
+		return err, err
+	}
+	der, err := EcdsaRawToDER(raw, size)
+	c.Assert(err, IsNil)
+	return verifier.Verify(msg, der), VerifyWithOptions(verifier, msg, raw, &VerifyOptions{AutoSignatureFormat: true})
+}
+
+func (EcdsaCAVPSuite) TestSigVer(c *C) {
+	vectors := readCAVPSigVerVectors(c, "testdata/SigVer.rsp")
+
+	counts := make(map[string]map[bool]int)
+	for _, v := range vectors {
+		c.Assert(cavpKeyTypes[v.section], Not(Equals), "", Commentf("unexpected section %s", v.section))
+		if counts[v.section] == nil {
+			counts[v.section] = make(map[bool]int)
+		}
+		counts[v.section][v.valid]++
+
+		derErr, rawErr := cavpVerify(c, v)
+		comment := Commentf("%s Msg = %s: %s", v.section, v.msg, v.expectedResult)
+		if v.valid {
+			c.Assert(derErr, IsNil, comment)
+			c.Assert(rawErr, IsNil, comment)
+		} else {
+			c.Assert(derErr, NotNil, comment)
+			c.Assert(rawErr, NotNil, comment)
+		}
+	}
+
+	// Both curves are covered, with passing and failing cases.
+	c.Assert(counts, HasLen, len(cavpKeyTypes))
+	for section, n := range counts {
+		c.Assert(n[true] > 0, Equals, true, Commentf("no valid vector in %s", section))
+		c.Assert(n[false] > 0, Equals, true, Commentf("no invalid vector in %s", section))
+	}
+}
+
+func (EcdsaCAVPSuite) TestSigVerDERToRaw(c *C) {
+	// The DER form of the vector signatures converts back to r||s.
+	for _, v := range readCAVPSigVerVectors(c, "testdata/SigVer.rsp") {
+		params, err := getEcdsaParams(cavpKeyTypes[v.section])
+		c.Assert(err, IsNil)
+		size := curveByteSize(params.curve)
+		raw, err := hex.DecodeString(strings.Repeat("0", 2*size-len(v.r)) + v.r + strings.Repeat("0", 2*size-len(v.s)) + v.s)
+		c.Assert(err, IsNil)
+		der, err := EcdsaRawToDER(raw, size)
+		c.Assert(err, IsNil)
+		back, err := EcdsaDERToRaw(der, size)
+		c.Assert(err, IsNil)
+		c.Assert(back, DeepEquals, raw)
+	}
+}
@@ -0,0 +1,85 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// EcdsaRawToDER converts a raw r||s (IEEE P1363) ECDSA signature, where r
+// and s are keySize bytes each, to ASN.1 DER.
+func EcdsaRawToDER(raw []byte, keySize int) ([]byte, error) {
+	if keySize <= 0 {
+		return nil, fmt.Errorf("%w: invalid ecdsa key size %d", ErrInvalidArgument, keySize)
+	}
+	if len(raw) != 2*keySize {
+		return nil, fmt.Errorf("%w: raw ecdsa signature must be %d bytes, got %d", ErrInvalidArgument, 2*keySize, len(raw))
+	}
+	r := new(big.Int).SetBytes(raw[:keySize])
+	s := new(big.Int).SetBytes(raw[keySize:])
+	if r.Sign() == 0 || s.Sign() == 0 {
+		return nil, fmt.Errorf("%w: ecdsa signature values must be positive", ErrInvalidArgument)
+	}
+
+	var b cryptobyte.Builder
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(r)
+		b.AddASN1BigInt(s)
+	})
+	return b.Bytes()
+}
+
+// EcdsaDERToRaw converts an ASN.1 DER ECDSA signature to raw r||s (IEEE
+// P1363) form, where r and s are left-padded to keySize bytes each.
+func EcdsaDERToRaw(der []byte, keySize int) ([]byte, error) {
+	if keySize <= 0 {
+		return nil, fmt.Errorf("%w: invalid ecdsa key size %d", ErrInvalidArgument, keySize)
+	}
+	sig := &ecdsaSignature{R: new(big.Int), S: new(big.Int)}
+	if err := parseEcdsaDERSignature(sig, der); err != nil {
+		return nil, err
+	}
+	if !isDERSignatureLength(der) {
+		return nil, errors.New("tuf: trailing data after ecdsa signature")
+	}
+	for _, v := range []*big.Int{sig.R, sig.S} {
+		if v.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: ecdsa signature values must be positive", ErrInvalidArgument)
+		}
+		if (v.BitLen()+7)/8 > keySize {
+			return nil, fmt.Errorf("%w: ecdsa signature value larger than %d bytes", ErrInvalidArgument, keySize)
+		}
+	}
+	raw := make([]byte, 2*keySize)
+	sig.R.FillBytes(raw[:keySize])
+	sig.S.FillBytes(raw[keySize:])
+	return raw, nil
+}
+
+// isDERSignatureLength reports whether der holds a single ASN.1 element with
+// no trailing data.
+func isDERSignatureLength(der []byte) bool {
+	input := cryptobyte.String(der)
+	var inner cryptobyte.String
+	return input.ReadASN1(&inner, cryptobyte_asn1.SEQUENCE) && input.Empty()
+}
+
+// DetectEcdsaCurveFromSignature returns the NIST curve whose raw r||s
+// signatures are as long as signature: 64 bytes for P-256, 96 bytes for
+// P-384 and 132 bytes for P-521. It returns false for other lengths, such
+// as DER signatures, whose length varies. This is only a hint for picking
+// the keySize of raw signatures when the key is mislabeled: other curves,
+// such as the brainpool ones, share these sizes, and the curve of the key
+// takes precedence whenever it is known.
+func DetectEcdsaCurveFromSignature(signature []byte) (elliptic.Curve, bool) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		if len(signature) == 2*curveByteSize(curve) {
+			return curve, true
+		}
+	}
+	return nil, false
+}
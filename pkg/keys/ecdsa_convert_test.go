@@ -0,0 +1,142 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+	. "gopkg.in/check.v1"
+)
+
+type EcdsaConvertSuite struct{}
+
+var _ = Suite(&EcdsaConvertSuite{})
+
+func (EcdsaConvertSuite) TestRoundTrip(c *C) {
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA_SHA2_P384, data.KeyTypeECDSA_SHA2_P521} {
+		signer, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		keySize := curveByteSize(signer.Curve)
+		msg := []byte("foo")
+		der, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+
+		raw, err := EcdsaDERToRaw(der, keySize)
+		c.Assert(err, IsNil)
+		c.Assert(raw, HasLen, 2*keySize)
+
+		// The raw signature verifies with the matching hash.
+		digest, err := signer.digest(msg)
+		c.Assert(err, IsNil)
+		r := new(big.Int).SetBytes(raw[:keySize])
+		s := new(big.Int).SetBytes(raw[keySize:])
+		c.Assert(ecdsa.Verify(&signer.PublicKey, digest, r, s), Equals, true)
+
+		back, err := EcdsaRawToDER(raw, keySize)
+		c.Assert(err, IsNil)
+		c.Assert(back, DeepEquals, der)
+	}
+}
+
+func (EcdsaConvertSuite) TestSmallValuesArePadded(c *C) {
+	raw := make([]byte, 64)
+	raw[31] = 1
+	raw[63] = 2
+	der, err := EcdsaRawToDER(raw, 32)
+	c.Assert(err, IsNil)
+	back, err := EcdsaDERToRaw(der, 32)
+	c.Assert(err, IsNil)
+	c.Assert(back, DeepEquals, raw)
+}
+
+func (EcdsaConvertSuite) TestInvalidInputs(c *C) {
+	_, err := EcdsaRawToDER(make([]byte, 63), 32)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	_, err = EcdsaRawToDER(make([]byte, 64), 32)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	_, err = EcdsaRawToDER(nil, 0)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+
+	_, err = EcdsaDERToRaw([]byte("not der"), 32)
+	c.Assert(err, NotNil)
+
+	// Values larger than the key size are rejected.
+	raw := make([]byte, 96)
+	raw[0], raw[95] = 1, 1
+	der, err := EcdsaRawToDER(raw, 48)
+	c.Assert(err, IsNil)
+	_, err = EcdsaDERToRaw(der, 32)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+
+	// Trailing data is rejected.
+	raw = make([]byte, 64)
+	raw[0], raw[63] = 1, 1
+	der, err = EcdsaRawToDER(raw, 32)
+	c.Assert(err, IsNil)
+	_, err = EcdsaDERToRaw(append(der, 0), 32)
+	c.Assert(err, ErrorMatches, "tuf: trailing data after ecdsa signature")
+}
+
+func (EcdsaConvertSuite) TestNegativeIntegers(c *C) {
+	var b cryptobyte.Builder
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(big.NewInt(-1))
+		b.AddASN1BigInt(big.NewInt(1))
+	})
+	der, err := b.Bytes()
+	c.Assert(err, IsNil)
+	_, err = EcdsaDERToRaw(der, 32)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	c.Assert(err, ErrorMatches, ".*ecdsa signature values must be positive")
+}
+
+func (EcdsaConvertSuite) TestRandomRoundTrip(c *C) {
+	for i := 0; i < 20; i++ {
+		raw := make([]byte, 64)
+		_, err := rand.Read(raw)
+		c.Assert(err, IsNil)
+		der, err := EcdsaRawToDER(raw, 32)
+		c.Assert(err, IsNil)
+		back, err := EcdsaDERToRaw(der, 32)
+		c.Assert(err, IsNil)
+		c.Assert(back, DeepEquals, raw)
+	}
+}
+
+func (EcdsaConvertSuite) TestDetectEcdsaCurveFromSignature(c *C) {
+	for _, t := range []struct {
+		size    int
+		curve   elliptic.Curve
+		keyType string
+	}{
+		{64, elliptic.P256(), data.KeyTypeECDSA_SHA2_P256},
+		{96, elliptic.P384(), data.KeyTypeECDSA_SHA2_P384},
+		{132, elliptic.P521(), data.KeyTypeECDSA_SHA2_P521},
+	} {
+		curve, ok := DetectEcdsaCurveFromSignature(make([]byte, t.size))
+		c.Assert(ok, Equals, true, Commentf("%d", t.size))
+		c.Assert(curve, Equals, t.curve)
+
+		// A raw signature made with the curve is detected as such.
+		signer, err := GenerateEcdsaKeyWithType(t.keyType)
+		c.Assert(err, IsNil)
+		der, err := signer.SignMessage([]byte("foo"))
+		c.Assert(err, IsNil)
+		raw, err := EcdsaDERToRaw(der, curveByteSize(t.curve))
+		c.Assert(err, IsNil)
+		curve, ok = DetectEcdsaCurveFromSignature(raw)
+		c.Assert(ok, Equals, true)
+		c.Assert(curve, Equals, t.curve)
+	}
+
+	for _, size := range []int{0, 63, 65, 70, 128, 133} {
+		curve, ok := DetectEcdsaCurveFromSignature(make([]byte, size))
+		c.Assert(ok, Equals, false, Commentf("%d", size))
+		c.Assert(curve, IsNil)
+	}
+}
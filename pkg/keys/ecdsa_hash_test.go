@@ -0,0 +1,47 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type EcdsaHashSuite struct{}
+
+var _ = Suite(&EcdsaHashSuite{})
+
+func (EcdsaHashSuite) TestVerifyEcdsaHash(c *C) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	hash := sha256.Sum256([]byte("foo"))
+	r, s, err := ecdsa.Sign(rand.Reader, priv, hash[:])
+	c.Assert(err, IsNil)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	c.Assert(VerifyEcdsaHash(&priv.PublicKey, hash[:], sig, 32), IsNil)
+
+	// A mismatched hash.
+	other := sha256.Sum256([]byte("bar"))
+	c.Assert(VerifyEcdsaHash(&priv.PublicKey, other[:], sig, 32), NotNil)
+
+	// A tampered signature.
+	tampered := append([]byte{}, sig...)
+	tampered[63] ^= 1
+	c.Assert(VerifyEcdsaHash(&priv.PublicKey, hash[:], tampered, 32), NotNil)
+
+	// Malformed arguments.
+	err = VerifyEcdsaHash(&priv.PublicKey, hash[:], sig[:63], 32)
+	c.Assert(errors.Is(err, ErrInvalid), Equals, true)
+	err = VerifyEcdsaHash(&priv.PublicKey, hash[:], sig, 48)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	err = VerifyEcdsaHash(&priv.PublicKey, nil, sig, 32)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	err = VerifyEcdsaHash(nil, hash[:], sig, 32)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+}
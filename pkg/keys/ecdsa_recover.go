@@ -0,0 +1,127 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrUntrustedRecoveredKey is returned by VerifyWithRecovery when the key
+// recovered from a signature is not one of the trusted keys.
+var ErrUntrustedRecoveredKey = errors.New("tuf: recovered ecdsa key is not trusted")
+
+// VerifyWithRecovery verifies signature, a raw r||s ECDSA signature over msg
+// in a compact format that does not carry the public key, by recovering the
+// key as in SEC 1 section 4.1.6 and checking that it is one of trusted. Bit 0
+// of recoveryID is the parity of the y coordinate of the signature point R,
+// and bit 1 is set when its x coordinate is r + N. The message is hashed with
+// the hash function of the key type registered for curve, such as SHA-256 for
+// P-256.
+//
+// The recovered key is compared to the encodings of all the trusted keys in
+// constant time. Recovery needs compressed points, so it is only supported
+// on curves of the form y² = x³ - 3x + b, which includes the NIST curves.
+func VerifyWithRecovery(msg, signature []byte, recoveryID byte, curve elliptic.Curve, trusted []*ecdsa.PublicKey) error {
+	if curve == nil {
+		return fmt.Errorf("%w: nil curve", ErrInvalidArgument)
+	}
+	if recoveryID > 3 {
+		return fmt.Errorf("%w: ecdsa recovery id must be between 0 and 3, got %d", ErrInvalidArgument, recoveryID)
+	}
+	_, params, err := ecdsaKeyTypeForCurve(curve)
+	if err != nil {
+		return err
+	}
+	keySize := curveByteSize(curve)
+	if len(signature) != 2*keySize {
+		return fmt.Errorf("%w: raw ecdsa signature is %d bytes, want %d", ErrInvalid, len(signature), 2*keySize)
+	}
+	h := params.hash.New()
+	h.Write(msg)
+	digest := h.Sum(nil)
+
+	sig := getEcdsaSignature()
+	defer putEcdsaSignature(sig)
+	sig.R.SetBytes(signature[:keySize])
+	sig.S.SetBytes(signature[keySize:])
+	pub, err := recoverEcdsaPublicKey(curve, digest, sig, recoveryID)
+	if err != nil {
+		return err
+	}
+	// Recovery yields a key for most inputs, which the signature must still
+	// verify against.
+	if err := verifyEcdsaDigest(pub, digest, sig); err != nil {
+		return err
+	}
+
+	recovered := elliptic.Marshal(curve, pub.X, pub.Y)
+	match := 0
+	for _, k := range trusted {
+		if k == nil || k.Curve != curve || k.X == nil || k.Y == nil {
+			continue
+		}
+		match |= subtle.ConstantTimeCompare(recovered, elliptic.Marshal(curve, k.X, k.Y))
+	}
+	if match != 1 {
+		return ErrUntrustedRecoveredKey
+	}
+	return nil
+}
+
+// recoverEcdsaPublicKey returns the public key Q = r⁻¹(sR - eG) for which sig
+// is a signature of digest, where R is the point selected by recoveryID.
+func recoverEcdsaPublicKey(curve elliptic.Curve, digest []byte, sig *ecdsaSignature, recoveryID byte) (*ecdsa.PublicKey, error) {
+	n := curve.Params().N
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(n) >= 0 || sig.S.Cmp(n) >= 0 {
+		return nil, errors.New("tuf: ecdsa signature values out of range")
+	}
+
+	x := new(big.Int).Set(sig.R)
+	if recoveryID&2 != 0 {
+		x.Add(x, n)
+	}
+	if x.Cmp(curve.Params().P) >= 0 {
+		return nil, errors.New("tuf: invalid ecdsa recovery id")
+	}
+	compressed := make([]byte, 1+curveByteSize(curve))
+	compressed[0] = 2 | recoveryID&1
+	x.FillBytes(compressed[1:])
+	rx, ry := unmarshalEcdsaPoint(curve, compressed)
+	if rx == nil {
+		return nil, errors.New("tuf: cannot recover ecdsa signature point")
+	}
+
+	// u1 = -e * r⁻¹ and u2 = s * r⁻¹, so that Q = u1 * G + u2 * R.
+	rInv := new(big.Int).ModInverse(sig.R, n)
+	e := ecdsaHashToInt(digest, n)
+	u1 := new(big.Int).Neg(e)
+	u1.Mul(u1, rInv).Mod(u1, n)
+	u2 := new(big.Int).Mul(sig.S, rInv)
+	u2.Mod(u2, n)
+
+	x1, y1 := curve.ScalarBaseMult(u1.Bytes())
+	x2, y2 := curve.ScalarMult(rx, ry, u2.Bytes())
+	qx, qy := curve.Add(x1, y1, x2, y2)
+	if qx.Sign() == 0 && qy.Sign() == 0 {
+		return nil, errors.New("tuf: recovered ecdsa key is the point at infinity")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: qx, Y: qy}, nil
+}
+
+// ecdsaHashToInt converts digest to an integer as ECDSA does, keeping its
+// leftmost bits up to the bit length of the order n.
+func ecdsaHashToInt(digest []byte, n *big.Int) *big.Int {
+	orderBits := n.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	e := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+	return e
+}
@@ -0,0 +1,73 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type EcdsaRecoverSuite struct{}
+
+var _ = Suite(&EcdsaRecoverSuite{})
+
+func (EcdsaRecoverSuite) TestVerifyWithRecovery(c *C) {
+	msg := []byte("foo")
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA_SHA2_P384, data.KeyTypeECDSA_SHA2_P521} {
+		comment := Commentf("%s", keyType)
+		signer, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		other, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		curve := signer.Curve
+		der, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+		sig, err := EcdsaDERToRaw(der, curveByteSize(curve))
+		c.Assert(err, IsNil)
+		trusted := []*ecdsa.PublicKey{&other.PublicKey, &signer.PublicKey}
+
+		// The key is recovered with exactly one of the parities of R, as
+		// r + N is almost never smaller than the field size.
+		recoveryID := byte(0)
+		matches := 0
+		for id := byte(0); id < 2; id++ {
+			if VerifyWithRecovery(msg, sig, id, curve, trusted) == nil {
+				recoveryID = id
+				matches++
+			}
+		}
+		c.Assert(matches, Equals, 1, comment)
+
+		// The recovered key is not trusted.
+		err = VerifyWithRecovery(msg, sig, recoveryID, curve, []*ecdsa.PublicKey{&other.PublicKey})
+		c.Assert(err, Equals, ErrUntrustedRecoveredKey, comment)
+		err = VerifyWithRecovery(msg, sig, recoveryID, curve, nil)
+		c.Assert(err, Equals, ErrUntrustedRecoveredKey, comment)
+
+		// Another message recovers another key.
+		c.Assert(VerifyWithRecovery([]byte("bar"), sig, recoveryID, curve, trusted), NotNil, comment)
+	}
+}
+
+func (EcdsaRecoverSuite) TestVerifyWithRecoveryInvalidArguments(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	trusted := []*ecdsa.PublicKey{&signer.PublicKey}
+	der, err := signer.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+	sig, err := EcdsaDERToRaw(der, 32)
+	c.Assert(err, IsNil)
+
+	err = VerifyWithRecovery([]byte("foo"), sig, 4, elliptic.P256(), trusted)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	err = VerifyWithRecovery([]byte("foo"), sig, 0, nil, trusted)
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	err = VerifyWithRecovery([]byte("foo"), der, 0, elliptic.P256(), trusted)
+	c.Assert(errors.Is(err, ErrInvalid), Equals, true)
+	err = VerifyWithRecovery([]byte("foo"), make([]byte, 64), 0, elliptic.P256(), trusted)
+	c.Assert(err, NotNil)
+	err = VerifyWithRecovery([]byte("foo"), sig, 0, elliptic.P224(), trusted)
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+}
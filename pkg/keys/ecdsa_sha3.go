@@ -0,0 +1,14 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/elliptic"
+
+	"github.com/theupdateframework/go-tuf/data"
+	// Registers crypto.SHA3_256.
+	_ "golang.org/x/crypto/sha3"
+)
+
+func init() {
+	RegisterEcdsaKeyType(data.KeyTypeECDSA_SHA3_P256, data.KeySchemeECDSA_SHA3_P256, elliptic.P256(), crypto.SHA3_256)
+}
@@ -0,0 +1,60 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"golang.org/x/crypto/sha3"
+	. "gopkg.in/check.v1"
+)
+
+type EcdsaSha3Suite struct{}
+
+var _ = Suite(&EcdsaSha3Suite{})
+
+func (EcdsaSha3Suite) TestSignVerify(c *C) {
+	c.Assert(crypto.SHA3_256.Available(), Equals, true)
+
+	signer, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA3_P256)
+	c.Assert(err, IsNil)
+	privKey, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	c.Assert(privKey.Type, Equals, data.KeyTypeECDSA_SHA3_P256)
+	c.Assert(privKey.Scheme, Equals, data.KeySchemeECDSA_SHA3_P256)
+	c.Assert(privKey.Scheme, Not(Equals), data.KeySchemeECDSA_SHA2_P256)
+
+	roundtrip, err := GetSigner(privKey)
+	c.Assert(err, IsNil)
+	c.Assert(roundtrip.PublicData().IDs(), DeepEquals, signer.PublicData().IDs())
+
+	msg := []byte("foo")
+	sig, err := roundtrip.SignMessage(msg)
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, sig), IsNil)
+	c.Assert(verifier.Verify([]byte("bar"), sig), NotNil)
+
+	// The signature is over the SHA3-256 digest of the message.
+	digest := sha3.Sum256(msg)
+	c.Assert(ecdsa.VerifyASN1(&signer.PublicKey, digest[:], sig), Equals, true)
+
+	// The same point as a SHA2 key does not verify the signature.
+	sha2Key := *signer.PublicData()
+	sha2Key.Type = data.KeyTypeECDSA_SHA2_P256
+	sha2Key.Scheme = data.KeySchemeECDSA_SHA2_P256
+	sha2Verifier, err := GetVerifier(&sha2Key)
+	c.Assert(err, IsNil)
+	c.Assert(sha2Verifier.Verify(msg, sig), NotNil)
+}
+
+func (EcdsaSha3Suite) TestRegisterUnavailableHash(c *C) {
+	defer SnapshotRegistry()()
+	c.Assert(func() {
+		RegisterEcdsaKeyType("ecdsa-foo", "ecdsa-foo", elliptic.P256(), crypto.Hash(0))
+	}, PanicMatches, `tuf: hash function .* of ecdsa key type "ecdsa-foo" is not available`)
+	_, ok := VerifierMap.Load("ecdsa-foo")
+	c.Assert(ok, Equals, false)
+}
@@ -0,0 +1,399 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type EcdsaSuite struct{}
+
+var _ = Suite(&EcdsaSuite{})
+
+func (EcdsaSuite) TestSignVerify(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	publicData := signer.PublicData()
+	pubKey, err := GetVerifier(publicData)
+	c.Assert(err, IsNil)
+	c.Assert(pubKey.Verify(msg, sig), IsNil)
+	c.Assert(pubKey.Verify([]byte("bar"), sig), NotNil)
+}
+
+func (EcdsaSuite) TestMarshalUnmarshal(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	privKey, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	c.Assert(privKey.Type, Equals, data.KeyTypeECDSA_SHA2_P256)
+
+	roundtrip, err := GetSigner(privKey)
+	c.Assert(err, IsNil)
+	c.Assert(roundtrip.PublicData().IDs(), DeepEquals, signer.PublicData().IDs())
+
+	publicData := signer.PublicData()
+	pubKey, err := GetVerifier(publicData)
+	c.Assert(err, IsNil)
+	c.Assert(pubKey.MarshalPublicKey(), DeepEquals, publicData)
+}
+
+func (EcdsaSuite) TestUnmarshalMismatchedPrivateKey(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	other, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	privKey, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+
+	var value ecdsaPrivateKeyValue
+	c.Assert(json.Unmarshal(privKey.Value, &value), IsNil)
+	value.Public = elliptic.Marshal(other.Curve, other.X, other.Y)
+	privKey.Value, err = json.Marshal(value)
+	c.Assert(err, IsNil)
+
+	_, err = GetSigner(privKey)
+	c.Assert(err, ErrorMatches, ".*ecdsa public and private keys do not match")
+}
+
+func (EcdsaSuite) TestPrivateScalarRoundTrip(c *C) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		c.Assert(err, IsNil)
+
+		scalar, err := EcdsaPrivateScalar(priv)
+		c.Assert(err, IsNil)
+		c.Assert(scalar, HasLen, curveByteSize(curve))
+
+		restored, err := EcdsaPrivateFromScalar(curve, scalar)
+		c.Assert(err, IsNil)
+		c.Assert(restored.Equal(priv), Equals, true)
+	}
+
+	// Small scalars are left-padded.
+	one := make([]byte, 32)
+	one[31] = 1
+	priv, err := EcdsaPrivateFromScalar(elliptic.P256(), one)
+	c.Assert(err, IsNil)
+	c.Assert(priv.X.Cmp(elliptic.P256().Params().Gx), Equals, 0)
+	scalar, err := EcdsaPrivateScalar(priv)
+	c.Assert(err, IsNil)
+	c.Assert(scalar, DeepEquals, one)
+}
+
+func (EcdsaSuite) TestPrivateFromScalarOutOfRange(c *C) {
+	curve := elliptic.P256()
+	_, err := EcdsaPrivateFromScalar(curve, make([]byte, 32))
+	c.Assert(err, ErrorMatches, "tuf: invalid ecdsa private key")
+	_, err = EcdsaPrivateFromScalar(curve, curve.Params().N.Bytes())
+	c.Assert(err, ErrorMatches, "tuf: invalid ecdsa private key")
+	_, err = EcdsaPrivateFromScalar(curve, make([]byte, 31))
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	_, err = EcdsaPrivateFromScalar(nil, make([]byte, 32))
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	_, err = EcdsaPrivateScalar(&ecdsa.PrivateKey{})
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+
+	// D = N fits in a scalar of the curve, but is not a valid scalar.
+	for _, d := range []*big.Int{curve.Params().N, new(big.Int).Add(curve.Params().N, big.NewInt(1))} {
+		priv := &ecdsa.PrivateKey{D: d}
+		priv.Curve = curve
+		_, err = EcdsaPrivateScalar(priv)
+		c.Assert(err, ErrorMatches, "tuf: invalid ecdsa private key")
+	}
+}
+
+func (EcdsaSuite) TestUnsupportedKeyType(c *C) {
+	_, err := GenerateEcdsaKeyWithType("ecdsa-sha2-unknown")
+	c.Assert(err, ErrorMatches, `tuf: unsupported ecdsa key type "ecdsa-sha2-unknown"`)
+}
+
+func (EcdsaSuite) TestCurveMismatch(c *C) {
+	signer, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P256)
+	c.Assert(err, IsNil)
+	p256 := signer.PublicData()
+
+	// A P-256 point declared as a P-384 key.
+	_, err = GetVerifier(&data.PublicKey{
+		Type:   data.KeyTypeECDSA_SHA2_P384,
+		Scheme: data.KeySchemeECDSA_SHA2_P384,
+		Value:  p256.Value,
+	})
+	c.Assert(errors.Is(err, ErrKeyMismatch), Equals, true)
+	_, err = GetVerifier(&data.PublicKey{
+		Type:   data.KeyTypeECDSA,
+		Scheme: data.KeySchemeECDSA_SHA2_P384,
+		Value:  p256.Value,
+	})
+	c.Assert(errors.Is(err, ErrKeyMismatch), Equals, true)
+
+	// The scheme contradicts the key type.
+	_, err = GetVerifier(&data.PublicKey{
+		Type:   data.KeyTypeECDSA_SHA2_P256,
+		Scheme: data.KeySchemeECDSA_SHA2_P384,
+		Value:  p256.Value,
+	})
+	c.Assert(errors.Is(err, ErrKeyMismatch), Equals, true)
+
+	// The private key path is checked too.
+	pk, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	pk.Type, pk.Scheme = data.KeyTypeECDSA_SHA2_P384, data.KeySchemeECDSA_SHA2_P384
+	_, err = GetSigner(pk)
+	c.Assert(errors.Is(err, ErrKeyMismatch), Equals, true)
+
+	// Generic keys with an unknown curve.
+	_, err = GetVerifier(&data.PublicKey{
+		Type:   data.KeyTypeECDSA,
+		Scheme: "ecdsa-sha2-unknown",
+		Value:  p256.Value,
+	})
+	c.Assert(errors.Is(err, ErrUnsupportedCurve), Equals, true)
+
+	// The declared P-256 key still loads.
+	_, err = GetVerifier(p256)
+	c.Assert(err, IsNil)
+}
+
+func (EcdsaSuite) TestVerifyEcdsaPoint(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	uncompressed := elliptic.Marshal(signer.Curve, signer.X, signer.Y)
+	compressed := elliptic.MarshalCompressed(signer.Curve, signer.X, signer.Y)
+	for _, point := range [][]byte{uncompressed, compressed} {
+		c.Assert(VerifyEcdsaPoint(data.KeyTypeECDSA_SHA2_P256, point, msg, sig), IsNil)
+		c.Assert(VerifyEcdsaPoint(data.KeyTypeECDSA_SHA2_P256, point, []byte("bar"), sig), NotNil)
+	}
+
+	// Points that are not on the curve are rejected.
+	offCurve := append([]byte{}, uncompressed...)
+	offCurve[len(offCurve)-1] ^= 1
+	c.Assert(VerifyEcdsaPoint(data.KeyTypeECDSA_SHA2_P256, offCurve, msg, sig), ErrorMatches, "tuf: malformed key: invalid ecdsa public key point")
+	c.Assert(VerifyEcdsaPoint(data.KeyTypeECDSA_SHA2_P256, compressed[1:], msg, sig), ErrorMatches, "tuf: malformed key: invalid ecdsa public key point")
+	c.Assert(VerifyEcdsaPoint("ecdsa-sha2-unknown", compressed, msg, sig), NotNil)
+}
+
+func (EcdsaSuite) TestCompressDecompressEcdsaPoint(c *C) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		c.Assert(err, IsNil)
+
+		compressed := CompressEcdsaPoint(&priv.PublicKey)
+		c.Assert(compressed, HasLen, 1+curveByteSize(curve))
+		pub, err := DecompressEcdsaPoint(curve, compressed)
+		c.Assert(err, IsNil)
+		c.Assert(pub.Equal(&priv.PublicKey), Equals, true)
+
+		uncompressed := elliptic.Marshal(curve, priv.X, priv.Y)
+		pub, err = DecompressEcdsaPoint(curve, uncompressed)
+		c.Assert(err, IsNil)
+		c.Assert(CompressEcdsaPoint(pub), DeepEquals, compressed)
+	}
+
+	_, err := DecompressEcdsaPoint(elliptic.P256(), []byte{0x02, 0x01})
+	c.Assert(err, ErrorMatches, "tuf: malformed key: invalid ecdsa public key point")
+}
+
+func (EcdsaSuite) TestPointAtInfinity(c *C) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		size := curveByteSize(curve)
+		for _, point := range [][]byte{
+			{0},
+			append([]byte{4}, make([]byte, 2*size)...),
+		} {
+			_, err := DecompressEcdsaPoint(curve, point)
+			c.Assert(errors.Is(err, ErrMalformedKey), Equals, true, Commentf("%x", point))
+		}
+	}
+
+	value, err := json.Marshal(ecdsaVerifier{PublicKey: append([]byte{4}, make([]byte, 64)...)})
+	c.Assert(err, IsNil)
+	_, err = GetVerifier(&data.PublicKey{
+		Type:   data.KeyTypeECDSA_SHA2_P256,
+		Scheme: data.KeySchemeECDSA_SHA2_P256,
+		Value:  value,
+	})
+	c.Assert(errors.Is(err, ErrMalformedKey), Equals, true)
+}
+
+func (EcdsaSuite) TestGenericEcdsaCurveDetection(c *C) {
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA_SHA2_P384, data.KeyTypeECDSA_SHA2_P521} {
+		signer, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		msg := []byte("foo")
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+
+		// Parse the key as a generic ECDSA key without any curve hint.
+		pub := signer.PublicData()
+		generic := &data.PublicKey{
+			Type:       data.KeyTypeECDSA,
+			Algorithms: pub.Algorithms,
+			Value:      pub.Value,
+		}
+		verifier, err := GetVerifier(generic)
+		c.Assert(err, IsNil)
+		c.Assert(verifier.(*ecdsaVerifier).params.curve, Equals, signer.Curve)
+		c.Assert(verifier.Verify(msg, sig), IsNil)
+
+	}
+}
+
+func (EcdsaSuite) TestCompressedKeyRejected(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	value, err := json.Marshal(ecdsaVerifier{PublicKey: CompressEcdsaPoint(&signer.PublicKey)})
+	c.Assert(err, IsNil)
+
+	// A compressed point would give the key a second key ID, under which
+	// its signatures would count twice toward a threshold.
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA} {
+		_, err = GetVerifier(&data.PublicKey{Type: keyType, Value: value})
+		c.Assert(errors.Is(err, ErrMalformedKey), Equals, true)
+	}
+
+	// The same holds for the public value of a private key.
+	priv, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	var privValue ecdsaPrivateKeyValue
+	c.Assert(json.Unmarshal(priv.Value, &privValue), IsNil)
+	privValue.Public = CompressEcdsaPoint(&signer.PublicKey)
+	priv.Value, err = json.Marshal(privValue)
+	c.Assert(err, IsNil)
+	_, err = GetSigner(priv)
+	c.Assert(err, NotNil)
+	_, err = GetSignerWithOptions(priv, &SignerOptions{SkipConsistencyCheck: true})
+	c.Assert(errors.Is(err, ErrMalformedKey), Equals, true)
+}
+
+func (EcdsaSuite) TestGenericEcdsaWithScheme(c *C) {
+	signer, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	c.Assert(err, IsNil)
+	pub := &data.PublicKey{
+		Type:   data.KeyTypeECDSA,
+		Scheme: data.KeySchemeECDSA_SHA2_P384,
+		Value:  signer.PublicData().Value,
+	}
+	verifier, err := GetVerifier(pub)
+	c.Assert(err, IsNil)
+	c.Assert(verifier.(*ecdsaVerifier).params.curve, Equals, elliptic.P384())
+}
+
+func (EcdsaSuite) TestGenericEcdsaUndetectable(c *C) {
+	for _, point := range []string{"04", "0401020304"} {
+		value, err := json.Marshal(map[string]string{"public": point})
+		c.Assert(err, IsNil)
+		_, err = GetVerifier(&data.PublicKey{Type: data.KeyTypeECDSA, Value: value})
+		c.Assert(err, ErrorMatches, ".*unable to detect the curve of ecdsa key")
+	}
+	_, err := GetVerifier(&data.PublicKey{Type: data.KeyTypeECDSA, Value: []byte(`{"public":""}`)})
+	c.Assert(errors.Is(err, ErrMissingPublicKey), Equals, true)
+}
+
+func (EcdsaSuite) TestParseEcdsaDERSignature(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	der, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	var expected ecdsaSignature
+	_, err = asn1.Unmarshal(der, &expected)
+	c.Assert(err, IsNil)
+
+	sig := getEcdsaSignature()
+	c.Assert(parseEcdsaDERSignature(sig, der), IsNil)
+	c.Assert(sig.R.Cmp(expected.R), Equals, 0)
+	c.Assert(sig.S.Cmp(expected.S), Equals, 0)
+	putEcdsaSignature(sig)
+
+	// Trailing data is ignored, as with encoding/asn1.
+	sig = getEcdsaSignature()
+	c.Assert(parseEcdsaDERSignature(sig, append(der, 0x00)), IsNil)
+	putEcdsaSignature(sig)
+
+	for _, bad := range [][]byte{nil, der[:len(der)-1], {0x30, 0x00}, []byte("not a signature")} {
+		sig = getEcdsaSignature()
+		c.Assert(parseEcdsaDERSignature(sig, bad), ErrorMatches, "tuf: invalid ecdsa signature encoding")
+		putEcdsaSignature(sig)
+	}
+
+	// Pooled signatures are reset after use and verification still works.
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	for i := 0; i < 10; i++ {
+		c.Assert(verifier.Verify(msg, der), IsNil)
+		c.Assert(verifier.Verify(msg, der[:len(der)-1]), NotNil)
+	}
+}
+
+func BenchmarkEcdsaVerify(b *testing.B) {
+	signer, err := GenerateEcdsaKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	verifier, err := GetVerifier(signer.PublicData())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := verifier.Verify(msg, sig); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func (EcdsaSuite) TestVerifyZeroKeySize(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	verifier := &ecdsaVerifier{
+		PublicKey: elliptic.Marshal(signer.Curve, signer.X, signer.Y),
+		params: &ecdsaParams{
+			curve: &elliptic.CurveParams{Name: "misconfigured"},
+			hash:  crypto.SHA256,
+		},
+	}
+	for _, s := range [][]byte{nil, {}, sig} {
+		err := VerifyWithOptions(verifier, msg, s, &VerifyOptions{AutoSignatureFormat: true})
+		c.Assert(errors.Is(err, ErrInvalid), Equals, true)
+		c.Assert(err, ErrorMatches, ".*invalid ecdsa key size")
+	}
+}
+
+func (EcdsaSuite) TestVerifyEmptySignature(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	for _, opts := range []*VerifyOptions{nil, {AutoSignatureFormat: true}} {
+		err := VerifyWithOptions(verifier, []byte("foo"), nil, opts)
+		c.Assert(errors.Is(err, ErrInvalid), Equals, true)
+		c.Assert(err, ErrorMatches, ".*empty ecdsa signature")
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"crypto"
 	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 
 	"github.com/theupdateframework/go-tuf/data"
 )
@@ -13,6 +16,7 @@ import (
 func init() {
 	SignerMap.Store(data.KeySchemeEd25519, NewP256Signer)
 	VerifierMap.Store(data.KeySchemeEd25519, NewP256Verifier)
+	RegisterKeySchemes(data.KeyTypeEd25519, data.KeySchemeEd25519)
 }
 
 func NewP256Signer() Signer {
@@ -33,12 +37,36 @@ func (e *ed25519Verifier) Public() string {
 }
 
 func (e *ed25519Verifier) Verify(msg, sig []byte) error {
+	if e.key != nil {
+		if err := checkPureEd25519Scheme(e.key.Scheme); err != nil {
+			return err
+		}
+	}
+	if isEdLowOrder(e.PublicKey) {
+		return errors.New("tuf: ed25519 public key has low order")
+	}
+	// Reject malformed signatures upfront rather than relying on the
+	// checks of the standard library, as for ECDSA.
+	if len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: ed25519 signature must be %d bytes, got %d", ErrInvalid, ed25519.SignatureSize, len(sig))
+	}
 	if !ed25519.Verify([]byte(e.PublicKey), msg, sig) {
-		return errors.New("tuf: ed25519 signature verification failed")
+		return fmt.Errorf("%w: ed25519 signature verification failed", ErrInvalid)
 	}
 	return nil
 }
 
+func (e *ed25519Verifier) checkSignatureFormat(sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		return errors.New("tuf: unexpected signature length for ed25519 key")
+	}
+	return nil
+}
+
+func (e *ed25519Verifier) normalizedValue() (json.RawMessage, error) {
+	return json.Marshal(ed25519Verifier{PublicKey: e.PublicKey})
+}
+
 func (e *ed25519Verifier) MarshalPublicKey() *data.PublicKey {
 	return e.key
 }
@@ -48,12 +76,55 @@ func (e *ed25519Verifier) UnmarshalPublicKey(key *data.PublicKey) error {
 	if err := json.Unmarshal(key.Value, e); err != nil {
 		return err
 	}
+	if len(e.PublicKey) == 0 {
+		return ErrMissingPublicKey
+	}
 	if len(e.PublicKey) != ed25519.PublicKeySize {
 		return errors.New("tuf: unexpected public key length for ed25519 key")
 	}
+	if isEdLowOrder(e.PublicKey) {
+		return errors.New("tuf: ed25519 public key has low order")
+	}
 	return nil
 }
 
+// edLowOrderPoints is the set of encodings of points in the small-order
+// subgroup of edwards25519. Signatures made with such a key are trivially
+// forgeable, so these keys are rejected.
+var edLowOrderPoints = [][]byte{
+	// The identity point (order 1): 0100000000000000000000000000000000000000000000000000000000000000
+	{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	// The point of order 2: ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f
+	{0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f},
+	// The all-zero key, a point of order 4: 0000000000000000000000000000000000000000000000000000000000000000
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	// The other point of order 4: 0000000000000000000000000000000000000000000000000000000000000080
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80},
+	// Points of order 8: 26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05
+	{0x26, 0xe8, 0x95, 0x8f, 0xc2, 0xb2, 0x27, 0xb0, 0x45, 0xc3, 0xf4, 0x89, 0xf2, 0xef, 0x98, 0xf0, 0xd5, 0xdf, 0xac, 0x05, 0xd3, 0xc6, 0x33, 0x39, 0xb1, 0x38, 0x02, 0x88, 0x6d, 0x53, 0xfc, 0x05},
+	// 26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85
+	{0x26, 0xe8, 0x95, 0x8f, 0xc2, 0xb2, 0x27, 0xb0, 0x45, 0xc3, 0xf4, 0x89, 0xf2, 0xef, 0x98, 0xf0, 0xd5, 0xdf, 0xac, 0x05, 0xd3, 0xc6, 0x33, 0x39, 0xb1, 0x38, 0x02, 0x88, 0x6d, 0x53, 0xfc, 0x85},
+	// c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a
+	{0xc7, 0x17, 0x6a, 0x70, 0x3d, 0x4d, 0xd8, 0x4f, 0xba, 0x3c, 0x0b, 0x76, 0x0d, 0x10, 0x67, 0x0f, 0x2a, 0x20, 0x53, 0xfa, 0x2c, 0x39, 0xcc, 0xc6, 0x4e, 0xc7, 0xfd, 0x77, 0x92, 0xac, 0x03, 0x7a},
+	// c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa
+	{0xc7, 0x17, 0x6a, 0x70, 0x3d, 0x4d, 0xd8, 0x4f, 0xba, 0x3c, 0x0b, 0x76, 0x0d, 0x10, 0x67, 0x0f, 0x2a, 0x20, 0x53, 0xfa, 0x2c, 0x39, 0xcc, 0xc6, 0x4e, 0xc7, 0xfd, 0x77, 0x92, 0xac, 0x03, 0xfa},
+	// Non-canonical encodings of the order 4 point (y = p) and of the
+	// identity (y = p + 1), which the decoder also accepts:
+	// edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f
+	{0xed, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f},
+	// eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f
+	{0xee, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f},
+}
+
+// isEdLowOrder reports whether pub is the encoding of a small-order point.
+func isEdLowOrder(pub []byte) bool {
+	lowOrder := 0
+	for _, p := range edLowOrderPoints {
+		lowOrder |= subtle.ConstantTimeCompare(pub, p)
+	}
+	return lowOrder == 1
+}
+
 type Ed25519PrivateKeyValue struct {
 	Public  data.HexBytes `json:"public"`
 	Private data.HexBytes `json:"private"`
@@ -68,7 +139,11 @@ type ed25519Signer struct {
 }
 
 func GenerateEd25519Key() (*ed25519Signer, error) {
-	_, private, err := ed25519.GenerateKey(rand.Reader)
+	return generateEd25519Key(rand.Reader)
+}
+
+func generateEd25519Key(r io.Reader) (*ed25519Signer, error) {
+	_, private, err := ed25519.GenerateKey(r)
 	if err != nil {
 		return nil, err
 	}
@@ -92,10 +167,62 @@ func NewEd25519Signer(keyValue Ed25519PrivateKeyValue) *ed25519Signer {
 	}
 }
 
+// SignMessage returns the pure Ed25519 signature of message, which is not
+// hashed beforehand: passing the SHA-512 digest of a message signs that
+// digest, and the signature does not verify against the message with any
+// implementation. Use NewEd25519phSigner to sign digests.
 func (e *ed25519Signer) SignMessage(message []byte) ([]byte, error) {
+	if err := checkPureEd25519Scheme(e.keyScheme); err != nil {
+		return nil, err
+	}
 	return e.Sign(rand.Reader, message, crypto.Hash(0))
 }
 
+// isEd25519Variant reports whether scheme is the scheme of an RFC 8032
+// variant of Ed25519, Ed25519ctx or Ed25519ph.
+func isEd25519Variant(scheme string) bool {
+	return scheme == data.KeySchemeEd25519ctx || scheme == data.KeySchemeEd25519ph
+}
+
+// allowsEd25519Variant reports whether keys of scheme may be used for the
+// variant of scheme variant: pure ed25519 keys and the keys of that variant,
+// but not the keys of other variants.
+func allowsEd25519Variant(scheme, variant string) bool {
+	return scheme == variant || !isEd25519Variant(scheme)
+}
+
+// checkPureEd25519Scheme makes sure that keys of the scheme of a variant are
+// only used through the wrapper of that variant, rather than for pure
+// Ed25519 signatures.
+func checkPureEd25519Scheme(scheme string) error {
+	if isEd25519Variant(scheme) {
+		return fmt.Errorf("%w: %s keys do not make or verify pure ed25519 signatures", ErrInvalidKey, scheme)
+	}
+	return nil
+}
+
+// withScheme returns a copy of pk with the given scheme.
+func withScheme(pk *data.PublicKey, scheme string) *data.PublicKey {
+	return &data.PublicKey{
+		Type:       pk.Type,
+		Scheme:     scheme,
+		Algorithms: pk.Algorithms,
+		Value:      pk.Value,
+		Expires:    pk.Expires,
+	}
+}
+
+// digest returns the message itself, as ed25519 signs the full message.
+func (e *ed25519Signer) digest(message []byte) ([]byte, error) {
+	return message, nil
+}
+
+// deterministic reports true, as ed25519 derives its nonces from the key and
+// the message.
+func (e *ed25519Signer) deterministic() bool {
+	return true
+}
+
 func (e *ed25519Signer) MarshalPrivateKey() (*data.PrivateKey, error) {
 	valueBytes, err := json.Marshal(Ed25519PrivateKeyValue{
 		Public:  data.HexBytes([]byte(e.PrivateKey.Public().(ed25519.PublicKey))),
@@ -113,10 +240,33 @@ func (e *ed25519Signer) MarshalPrivateKey() (*data.PrivateKey, error) {
 }
 
 func (e *ed25519Signer) UnmarshalPrivateKey(key *data.PrivateKey) error {
+	return e.unmarshalPrivateKeyWithOptions(key, &SignerOptions{})
+}
+
+func (e *ed25519Signer) unmarshalPrivateKeyWithOptions(key *data.PrivateKey, opts *SignerOptions) error {
 	keyValue := &Ed25519PrivateKeyValue{}
 	if err := json.Unmarshal(key.Value, keyValue); err != nil {
 		return err
 	}
+	if len(keyValue.Public) == 0 {
+		return ErrMissingPublicKey
+	}
+	if len(keyValue.Private) != ed25519.PrivateKeySize {
+		return errors.New("tuf: invalid ed25519 private key")
+	}
+	if !opts.SkipConsistencyCheck {
+		// Make sure the provided public key, and the public half of the
+		// private key, match the key derived from the private seed.
+		// Both comparisons are always made, so that the timing does not
+		// tell which of them failed.
+		derived := ed25519.NewKeyFromSeed(ed25519.PrivateKey(keyValue.Private).Seed())
+		public := derived.Public().(ed25519.PublicKey)
+		match := subtle.ConstantTimeCompare(public, keyValue.Public) &
+			subtle.ConstantTimeCompare(derived, keyValue.Private)
+		if match != 1 {
+			return errors.New("tuf: ed25519 public and private keys do not match")
+		}
+	}
 	*e = ed25519Signer{
 		PrivateKey:    ed25519.PrivateKey(data.HexBytes(keyValue.Private)),
 		keyType:       key.Type,
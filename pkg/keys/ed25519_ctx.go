@@ -0,0 +1,111 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrInvalidContext is returned when an Ed25519ctx context is empty or longer
+// than the 255 bytes allowed by RFC 8032.
+var ErrInvalidContext = errors.New("tuf: ed25519 context must be between 1 and 255 bytes")
+
+// NewEd25519ContextSigner wraps an ed25519 Signer so that it produces
+// Ed25519ctx (RFC 8032) signatures bound to context. Such signatures only
+// verify with a Verifier created by NewEd25519ContextVerifier with the same
+// context, which provides domain separation between uses of the same key.
+// The keys of the returned signer have the ed25519ctx scheme, which pure
+// ed25519 signers and verifiers refuse, so s may be a pure ed25519 signer or
+// one loaded from an Ed25519ctx private key.
+// It fails with ErrUnsupportedKeyType when built with Go before 1.20.
+func NewEd25519ContextSigner(s Signer, context []byte) (Signer, error) {
+	if err := checkEd25519Options(); err != nil {
+		return nil, err
+	}
+	signer, ok := s.(*ed25519Signer)
+	if !ok || !allowsEd25519Variant(signer.keyScheme, data.KeySchemeEd25519ctx) {
+		return nil, ErrInvalidKey
+	}
+	if err := checkEd25519Context(context); err != nil {
+		return nil, err
+	}
+	return &ed25519ctxSigner{ed25519Signer: signer, context: string(context)}, nil
+}
+
+// NewEd25519ContextVerifier wraps an ed25519 Verifier so that it verifies
+// Ed25519ctx (RFC 8032) signatures bound to context. v may be a pure ed25519
+// verifier or one loaded from an Ed25519ctx public key. It fails with
+// ErrUnsupportedKeyType when built with Go before 1.20.
+func NewEd25519ContextVerifier(v Verifier, context []byte) (Verifier, error) {
+	if err := checkEd25519Options(); err != nil {
+		return nil, err
+	}
+	verifier, ok := v.(*ed25519Verifier)
+	if !ok || verifier.key == nil || !allowsEd25519Variant(verifier.key.Scheme, data.KeySchemeEd25519ctx) {
+		return nil, ErrInvalidKey
+	}
+	if err := checkEd25519Context(context); err != nil {
+		return nil, err
+	}
+	return &ed25519ctxVerifier{
+		ed25519Verifier: verifier,
+		key:             withScheme(verifier.key, data.KeySchemeEd25519ctx),
+		context:         string(context),
+	}, nil
+}
+
+func checkEd25519Context(context []byte) error {
+	if len(context) == 0 || len(context) > 255 {
+		return ErrInvalidContext
+	}
+	return nil
+}
+
+type ed25519ctxSigner struct {
+	*ed25519Signer
+	context string
+}
+
+func (e *ed25519ctxSigner) PublicData() *data.PublicKey {
+	return withScheme(e.ed25519Signer.PublicData(), data.KeySchemeEd25519ctx)
+}
+
+func (e *ed25519ctxSigner) MarshalPrivateKey() (*data.PrivateKey, error) {
+	pk, err := e.ed25519Signer.MarshalPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	pk.Scheme = data.KeySchemeEd25519ctx
+	return pk, nil
+}
+
+func (e *ed25519ctxSigner) SignMessage(message []byte) ([]byte, error) {
+	return signEd25519WithOptions(e.PrivateKey, message, crypto.Hash(0), e.context)
+}
+
+type ed25519ctxVerifier struct {
+	*ed25519Verifier
+	key     *data.PublicKey
+	context string
+}
+
+func (e *ed25519ctxVerifier) MarshalPublicKey() *data.PublicKey {
+	return e.key
+}
+
+func (e *ed25519ctxVerifier) verifierContext() string {
+	return e.context
+}
+
+func (e *ed25519ctxVerifier) Verify(msg, sig []byte) error {
+	if isEdLowOrder(e.PublicKey) {
+		return errors.New("tuf: ed25519 public key has low order")
+	}
+	if err := verifyEd25519WithOptions(ed25519.PublicKey(e.PublicKey), msg, sig, crypto.Hash(0), e.context); err != nil {
+		return fmt.Errorf("%w: ed25519ctx signature verification failed", ErrInvalid)
+	}
+	return nil
+}
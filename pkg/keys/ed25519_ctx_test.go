@@ -0,0 +1,112 @@
+//go:build go1.20
+// +build go1.20
+
+package keys
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type Ed25519ContextSuite struct{}
+
+var _ = Suite(&Ed25519ContextSuite{})
+
+func (Ed25519ContextSuite) TestContextSeparation(c *C) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	plain, err := GetVerifier(key.PublicData())
+	c.Assert(err, IsNil)
+
+	rootSigner, err := NewEd25519ContextSigner(key, []byte("root"))
+	c.Assert(err, IsNil)
+	rootVerifier, err := NewEd25519ContextVerifier(plain, []byte("root"))
+	c.Assert(err, IsNil)
+	targetsVerifier, err := NewEd25519ContextVerifier(plain, []byte("targets"))
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	sig, err := rootSigner.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(rootVerifier.Verify(msg, sig), IsNil)
+	c.Assert(targetsVerifier.Verify(msg, sig), NotNil)
+	c.Assert(plain.Verify(msg, sig), NotNil)
+
+	// Pure ed25519 signatures must not verify under a context either.
+	plainSig, err := key.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(rootVerifier.Verify(msg, plainSig), NotNil)
+
+	// The wrapped signer exposes the underlying key with its own scheme,
+	// and so under its own key ID.
+	pub := rootSigner.PublicData()
+	c.Assert(pub.Scheme, Equals, data.KeySchemeEd25519ctx)
+	c.Assert(pub.Value, DeepEquals, key.PublicData().Value)
+	c.Assert(pub.IDs(), Not(DeepEquals), key.PublicData().IDs())
+	c.Assert(rootVerifier.MarshalPublicKey().IDs(), DeepEquals, pub.IDs())
+}
+
+func (Ed25519ContextSuite) TestMarshalContextKey(c *C) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ctxSigner, err := NewEd25519ContextSigner(key, []byte("root"))
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := ctxSigner.SignMessage(msg)
+	c.Assert(err, IsNil)
+	plainSig, err := key.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	// The marshalled keys do not load back as pure ed25519 keys.
+	priv, err := ctxSigner.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	c.Assert(priv.Scheme, Equals, data.KeySchemeEd25519ctx)
+	loaded, err := GetSigner(priv)
+	c.Assert(err, IsNil)
+	_, err = loaded.SignMessage(msg)
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+
+	verifier, err := GetVerifier(ctxSigner.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, plainSig), NotNil)
+	c.Assert(verifier.Verify(msg, sig), NotNil)
+
+	// They are used by wrapping them again.
+	loadedCtx, err := NewEd25519ContextSigner(loaded, []byte("root"))
+	c.Assert(err, IsNil)
+	ctxVerifier, err := NewEd25519ContextVerifier(verifier, []byte("root"))
+	c.Assert(err, IsNil)
+	again, err := loadedCtx.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(ctxVerifier.Verify(msg, again), IsNil)
+	c.Assert(ctxVerifier.Verify(msg, sig), IsNil)
+	c.Assert(loadedCtx.PublicData().IDs(), DeepEquals, ctxSigner.PublicData().IDs())
+}
+
+func (Ed25519ContextSuite) TestInvalidContext(c *C) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(key.PublicData())
+	c.Assert(err, IsNil)
+
+	tooLong := bytes.Repeat([]byte{'a'}, 256)
+	_, err = NewEd25519ContextSigner(key, tooLong)
+	c.Assert(err, Equals, ErrInvalidContext)
+	_, err = NewEd25519ContextVerifier(verifier, tooLong)
+	c.Assert(err, Equals, ErrInvalidContext)
+	_, err = NewEd25519ContextSigner(key, nil)
+	c.Assert(err, Equals, ErrInvalidContext)
+
+	_, err = NewEd25519ContextSigner(key, bytes.Repeat([]byte{'a'}, 255))
+	c.Assert(err, IsNil)
+}
+
+func (Ed25519ContextSuite) TestWrongKeyType(c *C) {
+	key, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	_, err = NewEd25519ContextSigner(key, []byte("root"))
+	c.Assert(err, Equals, ErrInvalidKey)
+}
@@ -0,0 +1,32 @@
+//go:build go1.20
+// +build go1.20
+
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+)
+
+// Ed25519ctx and Ed25519ph need the ed25519.Options of Go 1.20. On older
+// toolchains, ed25519_options_fallback.go provides the same functions, which
+// fail.
+
+// checkEd25519Options returns nil, as Ed25519ctx and Ed25519ph are
+// supported.
+func checkEd25519Options() error {
+	return nil
+}
+
+// signEd25519WithOptions signs message with priv, prehashed with hash for
+// Ed25519ph, or with context for Ed25519ctx.
+func signEd25519WithOptions(priv ed25519.PrivateKey, message []byte, hash crypto.Hash, context string) ([]byte, error) {
+	return priv.Sign(rand.Reader, message, &ed25519.Options{Hash: hash, Context: context})
+}
+
+// verifyEd25519WithOptions verifies sig, a signature of message by pub
+// created by signEd25519WithOptions with hash and context.
+func verifyEd25519WithOptions(pub ed25519.PublicKey, message, sig []byte, hash crypto.Hash, context string) error {
+	return ed25519.VerifyWithOptions(pub, message, sig, &ed25519.Options{Hash: hash, Context: context})
+}
@@ -0,0 +1,26 @@
+//go:build !go1.20
+// +build !go1.20
+
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"fmt"
+)
+
+var errEd25519Options = fmt.Errorf("%w: Ed25519ctx and Ed25519ph need Go 1.20 or later", ErrUnsupportedKeyType)
+
+// checkEd25519Options returns an error, as Ed25519ctx and Ed25519ph are not
+// supported before Go 1.20.
+func checkEd25519Options() error {
+	return errEd25519Options
+}
+
+func signEd25519WithOptions(priv ed25519.PrivateKey, message []byte, hash crypto.Hash, context string) ([]byte, error) {
+	return nil, errEd25519Options
+}
+
+func verifyEd25519WithOptions(pub ed25519.PublicKey, message, sig []byte, hash crypto.Hash, context string) error {
+	return errEd25519Options
+}
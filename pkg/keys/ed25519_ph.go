@@ -0,0 +1,120 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// NewEd25519phSigner wraps an ed25519 Signer so that it produces Ed25519ph
+// (RFC 8032) signatures, which sign the SHA-512 digest of the message. The
+// returned signer implements DigestSigner. Its keys have the ed25519ph
+// scheme, which pure ed25519 signers and verifiers refuse, so s may be a
+// pure ed25519 signer or one loaded from an Ed25519ph private key. It fails
+// with ErrUnsupportedKeyType when built with Go before 1.20.
+func NewEd25519phSigner(s Signer) (DigestSigner, error) {
+	if err := checkEd25519Options(); err != nil {
+		return nil, err
+	}
+	signer, ok := s.(*ed25519Signer)
+	if !ok || !allowsEd25519Variant(signer.keyScheme, data.KeySchemeEd25519ph) {
+		return nil, ErrInvalidKey
+	}
+	return &ed25519phSigner{ed25519Signer: signer}, nil
+}
+
+// NewEd25519phVerifier wraps an ed25519 Verifier so that it verifies
+// Ed25519ph (RFC 8032) signatures. The returned verifier implements
+// DigestVerifier. It never accepts pure Ed25519 signatures, and neither do
+// ed25519 verifiers accept Ed25519ph ones: there is no fallback from one
+// variant to the other. v may be a pure ed25519 verifier or one loaded from
+// an Ed25519ph public key. It fails with ErrUnsupportedKeyType when built
+// with Go before 1.20.
+func NewEd25519phVerifier(v Verifier) (DigestVerifier, error) {
+	if err := checkEd25519Options(); err != nil {
+		return nil, err
+	}
+	verifier, ok := v.(*ed25519Verifier)
+	if !ok || verifier.key == nil || !allowsEd25519Variant(verifier.key.Scheme, data.KeySchemeEd25519ph) {
+		return nil, ErrInvalidKey
+	}
+	return &ed25519phVerifier{
+		ed25519Verifier: verifier,
+		key:             withScheme(verifier.key, data.KeySchemeEd25519ph),
+	}, nil
+}
+
+// checkEd25519phDigest makes sure digest is a SHA-512 digest, as a digest
+// from another hash function would produce signatures that no other
+// implementation can verify.
+func checkEd25519phDigest(digest []byte) error {
+	if len(digest) != sha512.Size {
+		return fmt.Errorf("%w: ed25519ph digest must be %d bytes, got %d", ErrInvalidArgument, sha512.Size, len(digest))
+	}
+	return nil
+}
+
+type ed25519phSigner struct {
+	*ed25519Signer
+}
+
+func (e *ed25519phSigner) PublicData() *data.PublicKey {
+	return withScheme(e.ed25519Signer.PublicData(), data.KeySchemeEd25519ph)
+}
+
+func (e *ed25519phSigner) MarshalPrivateKey() (*data.PrivateKey, error) {
+	pk, err := e.ed25519Signer.MarshalPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	pk.Scheme = data.KeySchemeEd25519ph
+	return pk, nil
+}
+
+func (e *ed25519phSigner) SignMessage(message []byte) ([]byte, error) {
+	digest := sha512.Sum512(message)
+	return e.SignDigest(digest[:])
+}
+
+func (e *ed25519phSigner) SignDigest(digest []byte) ([]byte, error) {
+	if err := checkEd25519phDigest(digest); err != nil {
+		return nil, err
+	}
+	return signEd25519WithOptions(e.PrivateKey, digest, crypto.SHA512, "")
+}
+
+func (e *ed25519phSigner) digest(message []byte) ([]byte, error) {
+	digest := sha512.Sum512(message)
+	return digest[:], nil
+}
+
+type ed25519phVerifier struct {
+	*ed25519Verifier
+	key *data.PublicKey
+}
+
+func (e *ed25519phVerifier) MarshalPublicKey() *data.PublicKey {
+	return e.key
+}
+
+func (e *ed25519phVerifier) Verify(msg, sig []byte) error {
+	digest := sha512.Sum512(msg)
+	return e.VerifyDigest(digest[:], sig)
+}
+
+func (e *ed25519phVerifier) VerifyDigest(digest, sig []byte) error {
+	if err := checkEd25519phDigest(digest); err != nil {
+		return err
+	}
+	if isEdLowOrder(e.PublicKey) {
+		return errors.New("tuf: ed25519 public key has low order")
+	}
+	if err := verifyEd25519WithOptions(ed25519.PublicKey(e.PublicKey), digest, sig, crypto.SHA512, ""); err != nil {
+		return fmt.Errorf("%w: ed25519ph signature verification failed", ErrInvalid)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+//go:build go1.20
+// +build go1.20
+
+package keys
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type Ed25519phSuite struct{}
+
+var _ = Suite(&Ed25519phSuite{})
+
+func newEd25519phPair(c *C) (DigestSigner, DigestVerifier) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(key.PublicData())
+	c.Assert(err, IsNil)
+	phSigner, err := NewEd25519phSigner(key)
+	c.Assert(err, IsNil)
+	phVerifier, err := NewEd25519phVerifier(verifier)
+	c.Assert(err, IsNil)
+	return phSigner, phVerifier
+}
+
+func (Ed25519phSuite) TestSignVerify(c *C) {
+	signer, verifier := newEd25519phPair(c)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, sig), IsNil)
+	c.Assert(verifier.Verify([]byte("bar"), sig), NotNil)
+
+	digest := sha512.Sum512(msg)
+	c.Assert(verifier.VerifyDigest(digest[:], sig), IsNil)
+	digestSig, err := signer.SignDigest(digest[:])
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, digestSig), IsNil)
+
+	// SignDigest uses the Ed25519ph signer.
+	digestSig, err = SignDigest(signer, digest[:])
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, digestSig), IsNil)
+}
+
+func (Ed25519phSuite) TestDigestSize(c *C) {
+	signer, verifier := newEd25519phPair(c)
+	msg := []byte("foo")
+
+	wrong := sha256.Sum256(msg)
+	_, err := signer.SignDigest(wrong[:])
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+	c.Assert(err, ErrorMatches, ".*ed25519ph digest must be 64 bytes, got 32")
+	c.Assert(errors.Is(verifier.VerifyDigest(wrong[:], make([]byte, 64)), ErrInvalidArgument), Equals, true)
+
+	right := sha512.Sum512(msg)
+	sig, err := signer.SignDigest(right[:])
+	c.Assert(err, IsNil)
+	c.Assert(verifier.VerifyDigest(right[:], sig), IsNil)
+}
+
+// TestNoCrossVariantAcceptance checks that pure Ed25519 and Ed25519ph
+// signatures are never accepted by the verifier of the other variant, even
+// when signing the same bytes, so that a signature cannot be replayed under
+// another variant than it was made for.
+func (Ed25519phSuite) TestNoCrossVariantAcceptance(c *C) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pure, err := GetVerifier(key.PublicData())
+	c.Assert(err, IsNil)
+	phSigner, err := NewEd25519phSigner(key)
+	c.Assert(err, IsNil)
+	phVerifier, err := NewEd25519phVerifier(pure)
+	c.Assert(err, IsNil)
+	lenient := &VerifyOptions{AutoSignatureFormat: true, LenientSignatureLength: true}
+
+	msg := []byte("foo")
+	digest := sha512.Sum512(msg)
+	pureSig, err := key.SignMessage(msg)
+	c.Assert(err, IsNil)
+	phSig, err := phSigner.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(pure.Verify(msg, pureSig), IsNil)
+	c.Assert(phVerifier.Verify(msg, phSig), IsNil)
+
+	// A pure signature is rejected in ph mode, over the message or its
+	// digest, and so is a pure signature of the digest itself.
+	c.Assert(phVerifier.Verify(msg, pureSig), NotNil)
+	c.Assert(phVerifier.VerifyDigest(digest[:], pureSig), NotNil)
+	pureDigestSig, err := key.SignMessage(digest[:])
+	c.Assert(err, IsNil)
+	c.Assert(phVerifier.VerifyDigest(digest[:], pureDigestSig), NotNil)
+	c.Assert(VerifyWithOptions(phVerifier, msg, pureSig, lenient), NotNil)
+
+	// A ph signature is rejected in pure mode, over the message or its
+	// digest, whatever the options.
+	c.Assert(pure.Verify(msg, phSig), NotNil)
+	c.Assert(pure.Verify(digest[:], phSig), NotNil)
+	c.Assert(VerifyWithOptions(pure, msg, phSig, lenient), NotNil)
+	c.Assert(VerifyWithOptions(pure, digest[:], phSig, lenient), NotNil)
+
+	// Ed25519ctx signatures are accepted by neither.
+	ctxSigner, err := NewEd25519ContextSigner(key, []byte("root"))
+	c.Assert(err, IsNil)
+	ctxSig, err := ctxSigner.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(pure.Verify(msg, ctxSig), NotNil)
+	c.Assert(phVerifier.Verify(msg, ctxSig), NotNil)
+}
+
+func (Ed25519phSuite) TestMarshalPrehashKey(c *C) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	phSigner, err := NewEd25519phSigner(key)
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := phSigner.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	pub := phSigner.PublicData()
+	c.Assert(pub.Scheme, Equals, data.KeySchemeEd25519ph)
+	c.Assert(pub.IDs(), Not(DeepEquals), key.PublicData().IDs())
+
+	// The marshalled keys do not load back as pure ed25519 keys.
+	priv, err := phSigner.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	c.Assert(priv.Scheme, Equals, data.KeySchemeEd25519ph)
+	loaded, err := GetSigner(priv)
+	c.Assert(err, IsNil)
+	_, err = loaded.SignMessage(msg)
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+	verifier, err := GetVerifier(pub)
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, sig), NotNil)
+
+	// They are used by wrapping them again, but not as Ed25519ctx keys.
+	loadedPh, err := NewEd25519phSigner(loaded)
+	c.Assert(err, IsNil)
+	phVerifier, err := NewEd25519phVerifier(verifier)
+	c.Assert(err, IsNil)
+	c.Assert(phVerifier.MarshalPublicKey().IDs(), DeepEquals, pub.IDs())
+	again, err := loadedPh.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(phVerifier.Verify(msg, again), IsNil)
+	c.Assert(phVerifier.Verify(msg, sig), IsNil)
+	_, err = NewEd25519ContextSigner(loaded, []byte("root"))
+	c.Assert(err, Equals, ErrInvalidKey)
+	_, err = NewEd25519ContextVerifier(verifier, []byte("root"))
+	c.Assert(err, Equals, ErrInvalidKey)
+}
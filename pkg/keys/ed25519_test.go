@@ -1,7 +1,9 @@
 package keys
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
+	"errors"
 
 	"github.com/theupdateframework/go-tuf/data"
 	. "gopkg.in/check.v1"
@@ -34,3 +36,93 @@ func (Ed25519Suite) TestSignVerify(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(pubKey.Verify(msg, sig), IsNil)
 }
+
+func (Ed25519Suite) TestVerifySignatureLength(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+
+	for _, bad := range [][]byte{nil, sig[:63], append(append([]byte{}, sig...), 0)} {
+		err := verifier.Verify(msg, bad)
+		c.Assert(errors.Is(err, ErrInvalid), Equals, true, Commentf("length = %d", len(bad)))
+		c.Assert(err, ErrorMatches, "tuf: signature verification failed: ed25519 signature must be 64 bytes, got .*")
+	}
+}
+
+func (Ed25519Suite) TestUnmarshalLowOrderKeys(c *C) {
+	for _, point := range edLowOrderPoints {
+		keyValue, err := json.Marshal(ed25519Verifier{PublicKey: point})
+		c.Assert(err, IsNil)
+		key := &data.PublicKey{
+			Type:       data.KeyTypeEd25519,
+			Scheme:     data.KeySchemeEd25519,
+			Algorithms: data.HashAlgorithms,
+			Value:      keyValue,
+		}
+		_, err = GetVerifier(key)
+		c.Assert(err, ErrorMatches, ".*ed25519 public key has low order", Commentf("point %x", point))
+	}
+}
+
+func (Ed25519Suite) TestVerifyLowOrderKeys(c *C) {
+	// A verifier constructed without going through UnmarshalPublicKey must
+	// still reject small-order keys.
+	for _, point := range edLowOrderPoints {
+		verifier := &ed25519Verifier{PublicKey: point}
+		err := verifier.Verify([]byte("foo"), make([]byte, ed25519.SignatureSize))
+		c.Assert(err, ErrorMatches, "tuf: ed25519 public key has low order", Commentf("point %x", point))
+	}
+}
+
+func (Ed25519Suite) TestLowOrderPointsAreDistinct(c *C) {
+	seen := make(map[string]struct{})
+	for _, point := range edLowOrderPoints {
+		c.Assert(point, HasLen, ed25519.PublicKeySize)
+		seen[string(point)] = struct{}{}
+	}
+	c.Assert(seen, HasLen, len(edLowOrderPoints))
+	c.Assert(isEdLowOrder(make([]byte, ed25519.PublicKeySize)), Equals, true)
+
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	c.Assert(isEdLowOrder(signer.PrivateKey.Public().(ed25519.PublicKey)), Equals, false)
+}
+
+func (Ed25519Suite) TestUnmarshalMismatchedPrivateKey(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	other, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	seed := signer.PrivateKey.Seed()
+	public := signer.PrivateKey.Public().(ed25519.PublicKey)
+	otherPublic := other.PrivateKey.Public().(ed25519.PublicKey)
+
+	unmarshal := func(public, private []byte) error {
+		value, err := json.Marshal(Ed25519PrivateKeyValue{Public: public, Private: private})
+		c.Assert(err, IsNil)
+		return NewP256Signer().UnmarshalPrivateKey(&data.PrivateKey{
+			Type:   data.KeyTypeEd25519,
+			Scheme: data.KeySchemeEd25519,
+			Value:  value,
+		})
+	}
+
+	c.Assert(unmarshal(public, signer.PrivateKey), IsNil)
+
+	// The public key, the public half of the private key or both do not
+	// match the seed, and all fail the same way.
+	for _, t := range []struct {
+		public, private []byte
+	}{
+		{otherPublic, signer.PrivateKey},
+		{public, append(append([]byte{}, seed...), otherPublic...)},
+		{otherPublic, append(append([]byte{}, seed...), otherPublic...)},
+		{public[:16], signer.PrivateKey},
+	} {
+		c.Assert(unmarshal(t.public, t.private), ErrorMatches, "tuf: ed25519 public and private keys do not match")
+	}
+}
@@ -0,0 +1,50 @@
+package keys
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/encrypted"
+	"github.com/theupdateframework/go-tuf/internal/zeroize"
+)
+
+// ErrDecryptionFailed is returned by DecryptPrivateKey when the ciphertext
+// cannot be authenticated, which usually means the passphrase is wrong.
+var ErrDecryptionFailed = errors.New("tuf: private key decryption failed (wrong passphrase or corrupted data)")
+
+// EncryptPrivateKey encrypts pk with the encrypted package, using a key
+// derived from passphrase with scrypt and AES-256-GCM. The result is the JSON
+// envelope of the encrypted package, holding everything but the passphrase
+// needed to decrypt it.
+func EncryptPrivateKey(pk *data.PrivateKey, passphrase []byte) ([]byte, error) {
+	if pk == nil {
+		return nil, fmt.Errorf("%w: nil private key", ErrInvalidArgument)
+	}
+	plaintext, err := json.Marshal(pk)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroize.Bytes(plaintext)
+	return encrypted.EncryptWithCipher(plaintext, passphrase, encrypted.CipherAESGCM)
+}
+
+// DecryptPrivateKey decrypts a blob produced by EncryptPrivateKey, or by the
+// encrypted package with any of its ciphers. It returns ErrDecryptionFailed
+// if the passphrase is wrong or the blob was tampered with.
+func DecryptPrivateKey(blob, passphrase []byte) (*data.PrivateKey, error) {
+	plaintext, err := encrypted.Decrypt(blob, passphrase)
+	if errors.Is(err, encrypted.ErrDecryptionFailed) {
+		return nil, ErrDecryptionFailed
+	} else if err != nil {
+		return nil, err
+	}
+	defer zeroize.Bytes(plaintext)
+
+	pk := &data.PrivateKey{}
+	if err := json.Unmarshal(plaintext, pk); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
@@ -0,0 +1,104 @@
+package keys
+
+import (
+	"encoding/json"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/encrypted"
+	. "gopkg.in/check.v1"
+)
+
+type EncryptSuite struct{}
+
+var _ = Suite(&EncryptSuite{})
+
+func (EncryptSuite) TestRoundTrip(c *C) {
+	for _, gen := range []func() (Signer, error){
+		func() (Signer, error) { return GenerateEd25519Key() },
+		func() (Signer, error) { return GenerateEcdsaKey() },
+	} {
+		signer, err := gen()
+		c.Assert(err, IsNil)
+		pk, err := signer.MarshalPrivateKey()
+		c.Assert(err, IsNil)
+
+		passphrase := []byte("supersecret")
+		blob, err := EncryptPrivateKey(pk, passphrase)
+		c.Assert(err, IsNil)
+		c.Assert(json.Valid(blob), Equals, true)
+
+		dec, err := DecryptPrivateKey(blob, passphrase)
+		c.Assert(err, IsNil)
+		c.Assert(dec, DeepEquals, pk)
+
+		restored, err := GetSigner(dec)
+		c.Assert(err, IsNil)
+		c.Assert(restored.PublicData(), DeepEquals, signer.PublicData())
+	}
+}
+
+func (EncryptSuite) TestWrongPassphrase(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pk, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+
+	blob, err := EncryptPrivateKey(pk, []byte("supersecret"))
+	c.Assert(err, IsNil)
+
+	dec, err := DecryptPrivateKey(blob, []byte("wrong"))
+	c.Assert(err, Equals, ErrDecryptionFailed)
+	c.Assert(dec, IsNil)
+}
+
+func (EncryptSuite) TestTampered(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pk, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	passphrase := []byte("supersecret")
+	blob, err := EncryptPrivateKey(pk, passphrase)
+	c.Assert(err, IsNil)
+
+	var env map[string]interface{}
+	c.Assert(json.Unmarshal(blob, &env), IsNil)
+	env["ciphertext"] = "AAAA" + env["ciphertext"].(string)[4:]
+	tampered, err := json.Marshal(env)
+	c.Assert(err, IsNil)
+	_, err = DecryptPrivateKey(tampered, passphrase)
+	c.Assert(err, Equals, ErrDecryptionFailed)
+
+	c.Assert(json.Unmarshal(blob, &env), IsNil)
+	env["kdf"].(map[string]interface{})["params"].(map[string]interface{})["N"] = 65536
+	tampered, err = json.Marshal(env)
+	c.Assert(err, IsNil)
+	_, err = DecryptPrivateKey(tampered, passphrase)
+	c.Assert(err, ErrorMatches, "encrypted: unexpected kdf parameters")
+}
+
+func (EncryptSuite) TestDecryptEnvelopes(c *C) {
+	// A key encrypted by the previous implementation of EncryptPrivateKey,
+	// whose envelope was the same as the AES-GCM one of the encrypted
+	// package.
+	blob := []byte(`{"kdf":{"name":"scrypt","params":{"N":32768,"r":8,"p":1},"salt":"flfKcf31+GTR/4hIGcSebiMHi+r0es7XGEh2Uz7NAy4="},"cipher":{"name":"aes-256-gcm","nonce":"s4JKnQ683LeNueT/"},"ciphertext":"N1paCWfU4MnXDgEeLC9rFJ+B5rfdMDVxXwEbhX1Ps1ZK6CroaBH4jO7rJfQgyM7XOMvzMXoPw3yi9V8EUenA4GtHpruG6LSFyJWEl5Ug8O1BjhSdzlTudK+nld2nGvBn9bD/FpcAhWaxrGjGrSOwGmfv+YFfYu8iJgMc0lU="}`)
+	pk, err := DecryptPrivateKey(blob, []byte("supersecret"))
+	c.Assert(err, IsNil)
+	c.Assert(pk.Type, Equals, data.KeyTypeEd25519)
+	c.Assert(string(pk.Value), Equals, `{"public":"00"}`)
+
+	// Keys encrypted with NaCl secret box, as by the encrypted package by
+	// default, are decrypted too.
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	priv, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	plaintext, err := json.Marshal(priv)
+	c.Assert(err, IsNil)
+	blob, err = encrypted.Encrypt(plaintext, []byte("supersecret"))
+	c.Assert(err, IsNil)
+	pk, err = DecryptPrivateKey(blob, []byte("supersecret"))
+	c.Assert(err, IsNil)
+	c.Assert(pk, DeepEquals, priv)
+	_, err = DecryptPrivateKey(blob, []byte("wrong"))
+	c.Assert(err, Equals, ErrDecryptionFailed)
+}
@@ -0,0 +1,55 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/internal/zeroize"
+)
+
+// PrivateKeyEqual reports whether a and b hold the same private key, however
+// their values are encoded. Keys of different algorithms or curves are never
+// equal. The private parts are compared in constant time, and never included
+// in the returned errors.
+func PrivateKeyEqual(a, b *data.PrivateKey) (bool, error) {
+	sa, err := GetSigner(a)
+	if err != nil {
+		return false, err
+	}
+	sb, err := GetSigner(b)
+	if err != nil {
+		return false, err
+	}
+	switch ka := sa.(type) {
+	case *ed25519Signer:
+		kb, ok := sb.(*ed25519Signer)
+		if !ok {
+			return false, nil
+		}
+		// The ed25519 private key holds the seed and the public key,
+		// which GetSigner checked to be derived from the seed.
+		return subtle.ConstantTimeCompare(ka.PrivateKey.Seed(), kb.PrivateKey.Seed()) == 1, nil
+	case *ecdsaSigner:
+		kb, ok := sb.(*ecdsaSigner)
+		if !ok {
+			return false, nil
+		}
+		return ecdsaPrivateKeyEqual(ka.PrivateKey, kb.PrivateKey), nil
+	default:
+		return false, fmt.Errorf("%w: comparing %s keys is not supported", ErrUnsupportedKeyType, a.Type)
+	}
+}
+
+func ecdsaPrivateKeyEqual(a, b *ecdsa.PrivateKey) bool {
+	if a.Curve != b.Curve || !a.PublicKey.Equal(&b.PublicKey) {
+		return false
+	}
+	size := curveByteSize(a.Curve)
+	da := a.D.FillBytes(make([]byte, size))
+	db := b.D.FillBytes(make([]byte, size))
+	defer zeroize.Bytes(da)
+	defer zeroize.Bytes(db)
+	return subtle.ConstantTimeCompare(da, db) == 1
+}
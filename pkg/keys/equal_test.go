@@ -0,0 +1,90 @@
+package keys
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type EqualSuite struct{}
+
+var _ = Suite(&EqualSuite{})
+
+func (EqualSuite) TestPrivateKeyEqual(c *C) {
+	for _, gen := range []func() (Signer, error){
+		func() (Signer, error) { return GenerateEd25519Key() },
+		func() (Signer, error) { return GenerateEcdsaKey() },
+	} {
+		signer, err := gen()
+		c.Assert(err, IsNil)
+		pk, err := signer.MarshalPrivateKey()
+		c.Assert(err, IsNil)
+		other, err := gen()
+		c.Assert(err, IsNil)
+		otherPk, err := other.MarshalPrivateKey()
+		c.Assert(err, IsNil)
+
+		// Identical keys.
+		equal, err := PrivateKeyEqual(pk, pk)
+		c.Assert(err, IsNil)
+		c.Assert(equal, Equals, true)
+
+		// The same key with its value encoded differently.
+		var value map[string]string
+		c.Assert(json.Unmarshal(pk.Value, &value), IsNil)
+		for k, v := range value {
+			value[k] = strings.ToUpper(v)
+		}
+		reencoded := *pk
+		reencoded.Value, err = json.MarshalIndent(value, "", "  ")
+		c.Assert(err, IsNil)
+		c.Assert(string(reencoded.Value), Not(Equals), string(pk.Value))
+		equal, err = PrivateKeyEqual(pk, &reencoded)
+		c.Assert(err, IsNil)
+		c.Assert(equal, Equals, true)
+
+		// Different keys.
+		equal, err = PrivateKeyEqual(pk, otherPk)
+		c.Assert(err, IsNil)
+		c.Assert(equal, Equals, false)
+	}
+}
+
+func (EqualSuite) TestPrivateKeyEqualDifferentTypes(c *C) {
+	for _, t := range [][2]string{
+		{data.KeyTypeEd25519, data.KeyTypeECDSA_SHA2_P256},
+		{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA_SHA2_P384},
+	} {
+		a, err := GenerateRandom(t[0], nil)
+		c.Assert(err, IsNil)
+		b, err := GenerateRandom(t[1], nil)
+		c.Assert(err, IsNil)
+		equal, err := PrivateKeyEqual(a, b)
+		c.Assert(err, IsNil)
+		c.Assert(equal, Equals, false)
+	}
+}
+
+func (EqualSuite) TestPrivateKeyEqualErrors(c *C) {
+	pk, err := GenerateRandom(data.KeyTypeEd25519, nil)
+	c.Assert(err, IsNil)
+	_, err = PrivateKeyEqual(pk, &data.PrivateKey{Type: "foo"})
+	c.Assert(err, NotNil)
+
+	defer SnapshotRegistry()()
+	SignerMap.Store("foo", func() Signer { return nopSigner{} })
+	_, err = PrivateKeyEqual(&data.PrivateKey{Type: "foo"}, pk)
+	c.Assert(errors.Is(err, ErrUnsupportedKeyType), Equals, true)
+}
+
+// nopSigner is a custom signer accepting any private key.
+type nopSigner struct {
+	Signer
+}
+
+func (nopSigner) UnmarshalPrivateKey(*data.PrivateKey) error {
+	return nil
+}
@@ -0,0 +1,51 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrKeyExpired is returned by VerifyWithExpiry when the key has expired.
+var ErrKeyExpired = errors.New("tuf: key expired")
+
+// A Clock tells the current time to expiry checks, so that they can use a
+// time source other than the system clock, such as an NTP-backed clock or a
+// fake one in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock reading the system time with time.Now.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// VerifyWithExpiry verifies sig over msg with pk, like GetVerifier followed
+// by Verify, but first rejects keys whose expiry, if any, is before now.
+// Keys without an expiry never expire.
+func VerifyWithExpiry(msg, sig []byte, pk *data.PublicKey, now time.Time) error {
+	if pk.Expires != nil && now.After(*pk.Expires) {
+		return fmt.Errorf("%w at %s", ErrKeyExpired, pk.Expires.UTC().Format(time.RFC3339))
+	}
+	v, err := GetVerifier(pk)
+	if err != nil {
+		return err
+	}
+	return v.Verify(msg, sig)
+}
+
+// VerifyWithClock is VerifyWithExpiry with the current time read from clock.
+// A nil clock is the same as SystemClock.
+func VerifyWithClock(msg, sig []byte, pk *data.PublicKey, clock Clock) error {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return VerifyWithExpiry(msg, sig, pk, clock.Now())
+}
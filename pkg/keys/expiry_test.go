@@ -0,0 +1,101 @@
+package keys
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type ExpirySuite struct{}
+
+var _ = Suite(&ExpirySuite{})
+
+func (ExpirySuite) TestVerifyWithExpiry(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	// No expiry.
+	pk := signer.PublicData()
+	c.Assert(pk.Expires, IsNil)
+	c.Assert(VerifyWithExpiry(msg, sig, pk, now), IsNil)
+
+	// Not yet expired.
+	pk.Expires = &future
+	c.Assert(VerifyWithExpiry(msg, sig, pk, now), IsNil)
+	c.Assert(VerifyWithExpiry([]byte("bar"), sig, pk, now), NotNil)
+
+	// Expired.
+	pk.Expires = &past
+	err = VerifyWithExpiry(msg, sig, pk, now)
+	c.Assert(errors.Is(err, ErrKeyExpired), Equals, true)
+	c.Assert(err, ErrorMatches, "tuf: key expired at 2022-05-31T23:00:00Z")
+}
+
+// fakeClock is a Clock returning a fixed time.
+type fakeClock time.Time
+
+func (c fakeClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func (ExpirySuite) TestVerifyWithClock(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	pk := signer.PublicData()
+	expires := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	pk.Expires = &expires
+
+	// Just before and at the expiry, the key is valid.
+	c.Assert(VerifyWithClock(msg, sig, pk, fakeClock(expires.Add(-time.Nanosecond))), IsNil)
+	c.Assert(VerifyWithClock(msg, sig, pk, fakeClock(expires)), IsNil)
+	c.Assert(VerifyWithClock([]byte("bar"), sig, pk, fakeClock(expires)), NotNil)
+
+	// Just after, it is expired.
+	err = VerifyWithClock(msg, sig, pk, fakeClock(expires.Add(time.Nanosecond)))
+	c.Assert(errors.Is(err, ErrKeyExpired), Equals, true)
+
+	// The system clock is used by default.
+	err = VerifyWithClock(msg, sig, pk, nil)
+	c.Assert(errors.Is(err, ErrKeyExpired), Equals, true)
+	future := time.Now().Add(time.Hour)
+	pk.Expires = &future
+	c.Assert(VerifyWithClock(msg, sig, pk, nil), IsNil)
+	c.Assert(VerifyWithClock(msg, sig, pk, SystemClock), IsNil)
+}
+
+func (ExpirySuite) TestFromTUFKeyExpiry(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	pk := signer.PublicData()
+	expires := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	pk.Expires = &expires
+	raw, err := json.Marshal(pk)
+	c.Assert(err, IsNil)
+
+	parsed, id, err := FromTUFKey(raw)
+	c.Assert(err, IsNil)
+	c.Assert(parsed.Expires, NotNil)
+	c.Assert(parsed.Expires.Equal(expires), Equals, true)
+	c.Assert(id, Not(Equals), signer.PublicData().IDs()[0])
+
+	c.Assert(VerifyWithExpiry(msg, sig, parsed, expires.Add(-time.Second)), IsNil)
+	err = VerifyWithExpiry(msg, sig, parsed, expires.Add(time.Second))
+	c.Assert(errors.Is(err, ErrKeyExpired), Equals, true)
+}
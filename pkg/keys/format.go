@@ -0,0 +1,56 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// RSA signatures are as long as the modulus of the key, which is not known
+// without the key. ValidateSignatureFormat accepts the sizes of moduli from
+// 1024 to 16384 bits.
+const (
+	minRsaSignatureSize = 1024 / 8
+	maxRsaSignatureSize = 16384 / 8
+)
+
+// ValidateSignatureFormat checks that sig has the size and structure of a
+// signature for the key type or scheme alg, without performing any
+// cryptographic operation and without the key: it is a dry run rejecting
+// signatures that cannot possibly verify.
+//
+// ECDSA signatures must be ASN.1 DER encoded, or also raw r||s if
+// opts.AutoSignatureFormat is set, with values in the range of the curve of
+// alg. For the generic "ecdsa" type the curve is unknown, so only the
+// encoding is checked. A nil opts is the same as the zero VerifyOptions.
+func ValidateSignatureFormat(alg string, sig []byte, opts *VerifyOptions) error {
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+	switch alg {
+	case data.KeyTypeEd25519:
+		if len(sig) != ed25519.SignatureSize {
+			return fmt.Errorf("%w: ed25519 signature must be %d bytes, got %d", ErrInvalid, ed25519.SignatureSize, len(sig))
+		}
+		return nil
+	case data.KeyTypeRSASSA_PSS_SHA256, data.KeySchemeRSASSA_PSS_SHA256, data.KeySchemeRSA_PKCS1v15_SHA256:
+		if len(sig) < minRsaSignatureSize || len(sig) > maxRsaSignatureSize {
+			return fmt.Errorf("%w: rsa signature must be %d to %d bytes, got %d", ErrInvalid, minRsaSignatureSize, maxRsaSignatureSize, len(sig))
+		}
+		return nil
+	case data.KeyTypeECDSA:
+		if err := checkEcdsaSignatureFormat(nil, sig, opts); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalid, err)
+		}
+		return nil
+	}
+	params, err := getEcdsaParams(alg)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrUnsupportedKeyType, alg)
+	}
+	if err := checkEcdsaSignatureFormat(params.curve, sig, opts); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalid, err)
+	}
+	return nil
+}
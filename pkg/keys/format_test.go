@@ -0,0 +1,86 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type FormatSuite struct{}
+
+var _ = Suite(&FormatSuite{})
+
+func (FormatSuite) TestValidateSignatureFormatEd25519(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	sig, err := signer.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+
+	c.Assert(ValidateSignatureFormat(data.KeyTypeEd25519, sig, nil), IsNil)
+	for _, n := range []int{0, 63, 65} {
+		err := ValidateSignatureFormat(data.KeyTypeEd25519, make([]byte, n), nil)
+		c.Assert(errors.Is(err, ErrInvalid), Equals, true, Commentf("%d bytes", n))
+	}
+}
+
+func (FormatSuite) TestValidateSignatureFormatRsa(c *C) {
+	signer, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	sig, err := signer.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+
+	for _, alg := range []string{data.KeyTypeRSASSA_PSS_SHA256, data.KeySchemeRSASSA_PSS_SHA256, data.KeySchemeRSA_PKCS1v15_SHA256} {
+		c.Assert(ValidateSignatureFormat(alg, sig, nil), IsNil)
+		for _, n := range []int{0, 127, 2049} {
+			err := ValidateSignatureFormat(alg, make([]byte, n), nil)
+			c.Assert(errors.Is(err, ErrInvalid), Equals, true, Commentf("%s, %d bytes", alg, n))
+		}
+	}
+}
+
+func (FormatSuite) TestValidateSignatureFormatEcdsa(c *C) {
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA_SHA2_P384, data.KeyTypeECDSA_SHA2_P521} {
+		signer, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		sig, err := signer.SignMessage([]byte("foo"))
+		c.Assert(err, IsNil)
+		size := curveByteSize(signer.Curve)
+		raw, err := EcdsaDERToRaw(sig, size)
+		c.Assert(err, IsNil)
+
+		c.Assert(ValidateSignatureFormat(keyType, sig, nil), IsNil)
+		c.Assert(ValidateSignatureFormat(data.KeyTypeECDSA, sig, nil), IsNil)
+
+		// Raw signatures are only accepted when requested.
+		err = ValidateSignatureFormat(keyType, raw, nil)
+		c.Assert(errors.Is(err, ErrInvalid), Equals, true)
+		c.Assert(ValidateSignatureFormat(keyType, raw, &VerifyOptions{AutoSignatureFormat: true}), IsNil)
+
+		// Truncated, trailing data and values out of range.
+		n := signer.Curve.Params().N
+		tooLarge, err := asn1.Marshal(ecdsaSignature{R: n, S: big.NewInt(1)})
+		c.Assert(err, IsNil)
+		zero, err := asn1.Marshal(ecdsaSignature{R: big.NewInt(0), S: big.NewInt(1)})
+		c.Assert(err, IsNil)
+		for _, bad := range [][]byte{
+			nil,
+			sig[:len(sig)-1],
+			append(append([]byte{}, sig...), 0),
+			tooLarge,
+			zero,
+			bytes.Repeat([]byte{0xff}, 2*size),
+		} {
+			err := ValidateSignatureFormat(keyType, bad, nil)
+			c.Assert(errors.Is(err, ErrInvalid), Equals, true, Commentf("%s: %x", keyType, bad))
+		}
+	}
+}
+
+func (FormatSuite) TestValidateSignatureFormatUnsupported(c *C) {
+	err := ValidateSignatureFormat("foo", make([]byte, 64), nil)
+	c.Assert(errors.Is(err, ErrUnsupportedKeyType), Equals, true)
+}
@@ -0,0 +1,37 @@
+package keys
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// GenerateRandom generates a private key of the given key type, ed25519, a
+// registered ECDSA key type or RSASSA-PSS, using the random source r, or
+// crypto/rand if r is nil. Recent Go versions ignore r when generating ECDSA
+// and RSA keys, so only ed25519 keys are reproducible from a deterministic
+// source.
+func GenerateRandom(keyType string, r io.Reader) (*data.PrivateKey, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	var (
+		s   Signer
+		err error
+	)
+	if keyType == data.KeyTypeEd25519 {
+		s, err = generateEd25519Key(r)
+	} else if _, ok := ecdsaKeyTypes.Load(keyType); ok {
+		s, err = generateEcdsaKey(keyType, r)
+	} else if keyType == data.KeyTypeRSASSA_PSS_SHA256 {
+		s, err = generateRsaKey(data.KeySchemeRSASSA_PSS_SHA256, r)
+	} else {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedKeyType, keyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.MarshalPrivateKey()
+}
@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type GenerateSuite struct{}
+
+var _ = Suite(&GenerateSuite{})
+
+func (GenerateSuite) TestGenerateRandom(c *C) {
+	var keyTypes []string
+	ecdsaKeyTypes.Range(func(k, _ interface{}) bool {
+		keyTypes = append(keyTypes, k.(string))
+		return true
+	})
+	keyTypes = append(keyTypes, data.KeyTypeEd25519, data.KeyTypeRSASSA_PSS_SHA256)
+
+	msg := []byte("foo")
+	for _, keyType := range keyTypes {
+		comment := Commentf("key type = %s", keyType)
+		pk, err := GenerateRandom(keyType, nil)
+		c.Assert(err, IsNil, comment)
+		c.Assert(pk.Type, Equals, keyType, comment)
+
+		signer, err := GetSigner(pk)
+		c.Assert(err, IsNil, comment)
+		// The private key round trips.
+		again, err := signer.MarshalPrivateKey()
+		c.Assert(err, IsNil, comment)
+		c.Assert(again, DeepEquals, pk, comment)
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil, comment)
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil, comment)
+		c.Assert(verifier.Verify(msg, sig), IsNil, comment)
+	}
+}
+
+func (GenerateSuite) TestGenerateRandomDeterministic(c *C) {
+	seed := bytes.Repeat([]byte{1}, 32)
+	a, err := GenerateRandom(data.KeyTypeEd25519, bytes.NewReader(seed))
+	c.Assert(err, IsNil)
+	b, err := GenerateRandom(data.KeyTypeEd25519, bytes.NewReader(seed))
+	c.Assert(err, IsNil)
+	c.Assert(a, DeepEquals, b)
+}
+
+func (GenerateSuite) TestGenerateRandomUnsupported(c *C) {
+	for _, keyType := range []string{data.KeyTypeECDSA, "foo"} {
+		_, err := GenerateRandom(keyType, nil)
+		c.Assert(errors.Is(err, ErrUnsupportedKeyType), Equals, true, Commentf("key type = %s", keyType))
+	}
+}
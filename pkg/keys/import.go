@@ -0,0 +1,274 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// newSignerFromPrivateKey returns a Signer for a private key parsed by the
+// standard library, such as the ones returned by x509.ParsePKCS8PrivateKey.
+func newSignerFromPrivateKey(priv crypto.PrivateKey) (Signer, error) {
+	switch k := priv.(type) {
+	case ed25519.PrivateKey:
+		return NewEd25519Signer(Ed25519PrivateKeyValue{
+			Public:  data.HexBytes(k.Public().(ed25519.PublicKey)),
+			Private: data.HexBytes(k),
+		}), nil
+	case *ecdsa.PrivateKey:
+		keyType, params, err := ecdsaKeyTypeForPublicKey(&k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if k.D == nil {
+			return nil, fmt.Errorf("%w: ecdsa private key has no private scalar", ErrInvalidKey)
+		}
+		return &ecdsaSigner{
+			PrivateKey:    k,
+			hash:          params.hash,
+			keyType:       keyType,
+			keyScheme:     params.scheme,
+			keyAlgorithms: data.HashAlgorithms,
+		}, nil
+	case *rsa.PrivateKey:
+		if k.N == nil || k.D == nil {
+			return nil, fmt.Errorf("%w: rsa private key has no private exponent", ErrInvalidKey)
+		}
+		return &rsaSigner{PrivateKey: k}, nil
+	default:
+		var signer Signer
+		err := rangeKeyConverters(func(c *keyConverter) error {
+			if c.toSigner == nil {
+				return ErrUnsupportedKeyType
+			}
+			s, err := c.toSigner(priv)
+			signer = s
+			return err
+		})
+		return signer, err
+	}
+}
+
+// publicKeyFromCrypto returns the TUF public key for a public key parsed by
+// the standard library, such as the ones returned by x509.ParsePKIXPublicKey.
+func publicKeyFromCrypto(pub crypto.PublicKey) (*data.PublicKey, error) {
+	var (
+		keyType, scheme string
+		value           interface{}
+	)
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		keyType, scheme = data.KeyTypeEd25519, data.KeySchemeEd25519
+		value = ed25519Verifier{PublicKey: data.HexBytes(k)}
+	case *ecdsa.PublicKey:
+		t, params, err := ecdsaKeyTypeForPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keyType, scheme = t, params.scheme
+		value = ecdsaVerifier{PublicKey: elliptic.Marshal(k.Curve, k.X, k.Y)}
+	case *rsa.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keyType, scheme = data.KeyTypeRSASSA_PSS_SHA256, data.KeySchemeRSASSA_PSS_SHA256
+		value = rsaPublic{PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: der}))}
+	default:
+		var pk *data.PublicKey
+		err := rangeKeyConverters(func(c *keyConverter) error {
+			p, err := c.toPublic(pub)
+			pk = p
+			return err
+		})
+		return pk, err
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return &data.PublicKey{
+		Type:       keyType,
+		Scheme:     scheme,
+		Algorithms: data.HashAlgorithms,
+		Value:      valueBytes,
+	}, nil
+}
+
+// ToPublicKey returns the TUF public key for pub, a public key parsed by the
+// standard library, for use in metadata. Ed25519, ECDSA on a registered curve
+// and RSA keys are supported, like with VerifierFromPublicKey. A nil opts is
+// the same as the zero PublicKeyOptions.
+func ToPublicKey(pub crypto.PublicKey, opts *PublicKeyOptions) (*data.PublicKey, error) {
+	if opts == nil {
+		opts = &PublicKeyOptions{}
+	}
+	pk, err := publicKeyFromCrypto(pub)
+	if err != nil {
+		return nil, err
+	}
+	algorithms := data.HashAlgorithms
+	if len(opts.KeyIDHashAlgorithms) != 0 {
+		algorithms = opts.KeyIDHashAlgorithms
+	}
+	pk.Algorithms = append([]string(nil), algorithms...)
+	return pk, nil
+}
+
+// FromSPKI returns the TUF public key for der, an ASN.1 DER encoded PKIX
+// SubjectPublicKeyInfo such as found in certificates and TLS handshakes.
+// Ed25519, ECDSA on a registered curve and RSA keys are supported, like with
+// ToPublicKey; other algorithms and curves fail with ErrUnsupportedKeyType.
+func FromSPKI(der []byte) (*data.PublicKey, error) {
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err)
+	}
+	return publicKeyFromCrypto(pub)
+}
+
+// VerifierFromPublicKey returns a function verifying signatures by pub, a
+// public key parsed by the standard library, for example from an x509
+// certificate. Ed25519, ECDSA on a registered curve and RSA keys are
+// supported; RSA signatures are verified as RSASSA-PSS with SHA-256.
+func VerifierFromPublicKey(pub crypto.PublicKey) (func(msg, sig []byte) error, error) {
+	pk, err := publicKeyFromCrypto(pub)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := GetVerifier(pk)
+	if err != nil {
+		return nil, err
+	}
+	return verifier.Verify, nil
+}
+
+// SignerFromPrivateKey returns a Signer for priv, a private key parsed by the
+// standard library, for example with x509.ParsePKCS8PrivateKey. It is the
+// signing counterpart of VerifierFromPublicKey: signatures made by the
+// returned Signer verify with the verifier of its PublicData. Ed25519 keys,
+// ECDSA keys on a registered curve and RSA keys, which sign with RSASSA-PSS,
+// are supported, as are the keys of converters registered with
+// RegisterKeyConverter; other keys fail with ErrUnsupportedKeyType.
+func SignerFromPrivateKey(priv crypto.PrivateKey) (Signer, error) {
+	return newSignerFromPrivateKey(priv)
+}
+
+// ecdsaKeyTypeForPublicKey returns the registered ECDSA key type for pub,
+// checking that pub is fully constructed, as the standard library
+// dereferences its curve and coordinates without checking them.
+func ecdsaKeyTypeForPublicKey(pub *ecdsa.PublicKey) (string, *ecdsaParams, error) {
+	if pub == nil || pub.Curve == nil {
+		return "", nil, fmt.Errorf("%w: ecdsa key has no curve", ErrInvalidKey)
+	}
+	keyType, params, err := ecdsaKeyTypeForCurve(pub.Curve)
+	if err != nil {
+		return "", nil, err
+	}
+	if pub.X == nil || pub.Y == nil {
+		return "", nil, fmt.Errorf("%w: ecdsa key has no public point", ErrInvalidKey)
+	}
+	return keyType, params, nil
+}
+
+// ecdsaKeyTypeForCurve returns the registered ECDSA key type for curve.
+func ecdsaKeyTypeForCurve(curve elliptic.Curve) (string, *ecdsaParams, error) {
+	for _, keyType := range ecdsaAutoDetectKeyTypes {
+		if params, err := getEcdsaParams(keyType); err == nil && params.curve == curve {
+			return keyType, params, nil
+		}
+	}
+	var (
+		keyType string
+		params  *ecdsaParams
+	)
+	ecdsaKeyTypes.Range(func(k, v interface{}) bool {
+		if p := v.(*ecdsaParams); p.curve == curve {
+			keyType, params = k.(string), p
+			return false
+		}
+		return true
+	})
+	if params == nil {
+		return "", nil, ErrUnsupportedKeyType
+	}
+	return keyType, params, nil
+}
+
+// keyConverters lists the converters registered with RegisterKeyConverter,
+// in registration order, guarded by registryMu. The slice is replaced rather
+// than modified, so that it can be used once read without holding the lock.
+var keyConverters []*keyConverter
+
+// A keyConverter converts keys of the standard library or of third-party
+// packages to a custom key type.
+type keyConverter struct {
+	keyType  string
+	toPublic func(crypto.PublicKey) (*data.PublicKey, error)
+	toSigner func(crypto.PrivateKey) (Signer, error)
+}
+
+// RegisterKeyConverter registers conversions of public and private keys to
+// the custom key type keyType, so that ToPublicKey, VerifierFromPublicKey,
+// ParsePublicKey and the other functions taking standard library
+// keys support it. The built-in Ed25519, ECDSA and RSA conversions are tried
+// first, then the registered converters in registration order, which must
+// return ErrUnsupportedKeyType for keys they do not handle. Registering
+// keyType again replaces its converters, keeping their place in the order.
+// toSigner may be nil for verification-only key types.
+//
+// Verifiers and signers for keyType are registered separately, in
+// VerifierMap and SignerMap.
+func RegisterKeyConverter(keyType string, toPublic func(crypto.PublicKey) (*data.PublicKey, error), toSigner func(crypto.PrivateKey) (Signer, error)) error {
+	if keyType == "" {
+		return errors.New("tuf: empty key type")
+	}
+	if toPublic == nil {
+		return errors.New("tuf: nil public key converter")
+	}
+	converter := &keyConverter{keyType: keyType, toPublic: toPublic, toSigner: toSigner}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	converters := make([]*keyConverter, 0, len(keyConverters)+1)
+	replaced := false
+	for _, c := range keyConverters {
+		if c.keyType == keyType {
+			c, replaced = converter, true
+		}
+		converters = append(converters, c)
+	}
+	if !replaced {
+		converters = append(converters, converter)
+	}
+	keyConverters = converters
+	return nil
+}
+
+// rangeKeyConverters calls convert with each registered converter, in
+// registration order, until one returns an error other than
+// ErrUnsupportedKeyType, and returns that error, or ErrUnsupportedKeyType if
+// no converter handles the key.
+func rangeKeyConverters(convert func(*keyConverter) error) error {
+	// The converters are called without the lock held, so that they can
+	// register key types themselves.
+	registryMu.RLock()
+	converters := keyConverters
+	registryMu.RUnlock()
+
+	for _, c := range converters {
+		if err := convert(c); !errors.Is(err, ErrUnsupportedKeyType) {
+			return err
+		}
+	}
+	return ErrUnsupportedKeyType
+}
@@ -0,0 +1,308 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type ImportSuite struct{}
+
+var _ = Suite(&ImportSuite{})
+
+func (ImportSuite) TestVerifierFromPublicKey(c *C) {
+	msg := []byte("foo")
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, IsNil)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	c.Assert(err, IsNil)
+	p384Digest := sha512.Sum384(msg)
+	p384Sig, err := ecdsa.SignASN1(rand.Reader, p384, p384Digest[:])
+	c.Assert(err, IsNil)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, IsNil)
+	rsaDigest := sha256.Sum256(msg)
+	rsaSig, err := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, rsaDigest[:], nil)
+	c.Assert(err, IsNil)
+
+	for _, t := range []struct {
+		name string
+		pub  crypto.PublicKey
+		sig  []byte
+	}{
+		{"ed25519", edPub, ed25519.Sign(edPriv, msg)},
+		{"ecdsa p384", &p384.PublicKey, p384Sig},
+		{"rsa", &rsaKey.PublicKey, rsaSig},
+	} {
+		comment := Commentf("key = %s", t.name)
+		verify, err := VerifierFromPublicKey(t.pub)
+		c.Assert(err, IsNil, comment)
+		c.Assert(verify(msg, t.sig), IsNil, comment)
+		c.Assert(verify([]byte("bar"), t.sig), NotNil, comment)
+	}
+}
+
+func (ImportSuite) TestVerifierFromUnsupportedPublicKey(c *C) {
+	_, err := VerifierFromPublicKey(&dsa.PublicKey{})
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+
+	_, err = VerifierFromPublicKey(&ecdsa.PublicKey{Curve: elliptic.P224()})
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+}
+
+func (ImportSuite) TestToPublicKey(c *C) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, IsNil)
+
+	for _, t := range []struct {
+		opts       *PublicKeyOptions
+		algorithms []string
+	}{
+		{nil, []string{"sha256", "sha512"}},
+		{&PublicKeyOptions{}, []string{"sha256", "sha512"}},
+		{&PublicKeyOptions{KeyIDHashAlgorithms: []string{"sha256"}}, []string{"sha256"}},
+	} {
+		pk, err := ToPublicKey(pub, t.opts)
+		c.Assert(err, IsNil)
+		c.Assert(pk.Algorithms, DeepEquals, t.algorithms)
+
+		// The key IDs survive a round trip through JSON.
+		b, err := json.Marshal(pk)
+		c.Assert(err, IsNil)
+		roundtrip := &data.PublicKey{}
+		c.Assert(json.Unmarshal(b, roundtrip), IsNil)
+		c.Assert(roundtrip.Algorithms, DeepEquals, t.algorithms)
+		c.Assert(roundtrip.IDs(), DeepEquals, pk.IDs())
+	}
+
+	// The algorithms are part of the key IDs.
+	both, err := ToPublicKey(pub, nil)
+	c.Assert(err, IsNil)
+	one, err := ToPublicKey(pub, &PublicKeyOptions{KeyIDHashAlgorithms: []string{"sha256"}})
+	c.Assert(err, IsNil)
+	c.Assert(both.IDs(), Not(DeepEquals), one.IDs())
+
+	_, err = ToPublicKey(&dsa.PublicKey{}, nil)
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+}
+
+// dummyPublicKey and dummyPrivateKey are keys of a third-party package,
+// backed by ed25519 keys.
+func (ImportSuite) TestSignerFromPrivateKey(c *C) {
+	msg := []byte("foo")
+
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, IsNil)
+	privs := []crypto.PrivateKey{edPriv}
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		k, err := ecdsa.GenerateKey(curve, rand.Reader)
+		c.Assert(err, IsNil)
+		privs = append(privs, k)
+	}
+
+	for _, priv := range privs {
+		comment := Commentf("key = %T", priv)
+		signer, err := SignerFromPrivateKey(priv)
+		c.Assert(err, IsNil, comment)
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil, comment)
+
+		// The signature verifies with the verifier of the public data,
+		// and with one built from the standard library public key.
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil, comment)
+		c.Assert(verifier.Verify(msg, sig), IsNil, comment)
+		verify, err := VerifierFromPublicKey(priv.(crypto.Signer).Public())
+		c.Assert(err, IsNil, comment)
+		c.Assert(verify(msg, sig), IsNil, comment)
+		c.Assert(verify([]byte("bar"), sig), NotNil, comment)
+
+		// The signer round trips through its private key data.
+		pk, err := signer.MarshalPrivateKey()
+		c.Assert(err, IsNil, comment)
+		parsed, err := GetSigner(pk)
+		c.Assert(err, IsNil, comment)
+		c.Assert(parsed.PublicData().IDs(), DeepEquals, signer.PublicData().IDs(), comment)
+	}
+
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	c.Assert(err, IsNil)
+	_, err = SignerFromPrivateKey(p224)
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+	_, err = SignerFromPrivateKey(&dsa.PrivateKey{})
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+}
+
+func (ImportSuite) TestNilCurve(c *C) {
+	// Partially constructed keys fail cleanly rather than panicking.
+	_, err := VerifierFromPublicKey(&ecdsa.PublicKey{})
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+	_, err = ToPublicKey(&ecdsa.PublicKey{}, nil)
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+	_, err = NewCryptoSigner(&ecdsa.PrivateKey{})
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+	_, err = SignerFromPrivateKey(&ecdsa.PrivateKey{})
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+
+	// So do keys with a curve but no point or private scalar.
+	_, err = VerifierFromPublicKey(&ecdsa.PublicKey{Curve: elliptic.P256()})
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	_, err = SignerFromPrivateKey(&ecdsa.PrivateKey{PublicKey: p256.PublicKey})
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+}
+
+type dummyPublicKey struct{ key ed25519.PublicKey }
+type dummyPrivateKey struct{ key ed25519.PrivateKey }
+
+func (ImportSuite) TestRegisterKeyConverter(c *C) {
+	const dummyKeyType = "dummy"
+
+	restore := SnapshotRegistry()
+	defer restore()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, IsNil)
+	_, err = ToPublicKey(dummyPublicKey{pub}, nil)
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+
+	VerifierMap.Store(dummyKeyType, NewP256Verifier)
+	SignerMap.Store(dummyKeyType, NewP256Signer)
+	err = RegisterKeyConverter(dummyKeyType, func(pub crypto.PublicKey) (*data.PublicKey, error) {
+		k, ok := pub.(dummyPublicKey)
+		if !ok {
+			return nil, ErrUnsupportedKeyType
+		}
+		value, err := json.Marshal(ed25519Verifier{PublicKey: data.HexBytes(k.key)})
+		if err != nil {
+			return nil, err
+		}
+		return &data.PublicKey{Type: dummyKeyType, Scheme: dummyKeyType, Algorithms: data.HashAlgorithms, Value: value}, nil
+	}, func(priv crypto.PrivateKey) (Signer, error) {
+		k, ok := priv.(dummyPrivateKey)
+		if !ok {
+			return nil, ErrUnsupportedKeyType
+		}
+		return NewEd25519Signer(Ed25519PrivateKeyValue{
+			Public:  data.HexBytes(k.key.Public().(ed25519.PublicKey)),
+			Private: data.HexBytes(k.key),
+		}), nil
+	})
+	c.Assert(err, IsNil)
+
+	pk, err := ToPublicKey(dummyPublicKey{pub}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(pk.Type, Equals, dummyKeyType)
+	verifier, err := GetVerifier(pk)
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify([]byte("foo"), ed25519.Sign(priv, []byte("foo"))), IsNil)
+
+	verify, err := VerifierFromPublicKey(dummyPublicKey{pub})
+	c.Assert(err, IsNil)
+	c.Assert(verify([]byte("foo"), ed25519.Sign(priv, []byte("foo"))), IsNil)
+
+	signer, err := newSignerFromPrivateKey(dummyPrivateKey{priv})
+	c.Assert(err, IsNil)
+	sig, err := signer.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify([]byte("foo"), sig), IsNil)
+
+	// The built-in conversions and unsupported keys are unaffected.
+	pk, err = ToPublicKey(pub, nil)
+	c.Assert(err, IsNil)
+	c.Assert(pk.Type, Equals, data.KeyTypeEd25519)
+	_, err = ToPublicKey(&dsa.PublicKey{}, nil)
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+	_, err = newSignerFromPrivateKey(&dsa.PrivateKey{})
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+
+	c.Assert(RegisterKeyConverter("", nil, nil), NotNil)
+	c.Assert(RegisterKeyConverter(dummyKeyType, nil, nil), NotNil)
+
+	restore()
+	_, err = ToPublicKey(dummyPublicKey{pub}, nil)
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+}
+
+func (ImportSuite) TestFromSPKI(c *C) {
+	// The ed25519 public key of RFC 8410, section 10.1.
+	pk, err := FromSPKI(mustHex(c, "302a300506032b657003210019bf44096984cdfe8541bac167dc3b96c85086aa30b6b6cb0c5c38ad703166e1"))
+	c.Assert(err, IsNil)
+	c.Assert(pk.Type, Equals, data.KeyTypeEd25519)
+	c.Assert(pk.Scheme, Equals, data.KeySchemeEd25519)
+	c.Assert(string(pk.Value), Equals, `{"public":"19bf44096984cdfe8541bac167dc3b96c85086aa30b6b6cb0c5c38ad703166e1"}`)
+
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	der, err := x509.MarshalPKIXPublicKey(signer.PrivateKey.Public())
+	c.Assert(err, IsNil)
+	pk, err = FromSPKI(der)
+	c.Assert(err, IsNil)
+	c.Assert(pk.IDs(), DeepEquals, signer.PublicData().IDs())
+	verifier, err := GetVerifier(pk)
+	c.Assert(err, IsNil)
+	sig, err := signer.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify([]byte("foo"), sig), IsNil)
+
+	// An unregistered curve.
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	c.Assert(err, IsNil)
+	der, err = x509.MarshalPKIXPublicKey(&p224.PublicKey)
+	c.Assert(err, IsNil)
+	_, err = FromSPKI(der)
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+
+	_, err = FromSPKI([]byte("foo"))
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+	_, err = FromSPKI(append(der, 0))
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+}
+
+func (ImportSuite) TestKeyConverterOrder(c *C) {
+	restore := SnapshotRegistry()
+	defer restore()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, IsNil)
+	converter := func(keyType string) func(crypto.PublicKey) (*data.PublicKey, error) {
+		return func(pub crypto.PublicKey) (*data.PublicKey, error) {
+			if _, ok := pub.(dummyPublicKey); !ok {
+				return nil, ErrUnsupportedKeyType
+			}
+			return &data.PublicKey{Type: keyType}, nil
+		}
+	}
+
+	// Converters handling the same keys are tried in registration order,
+	// so the first one always wins.
+	keyTypes := []string{"dummy-1", "dummy-2", "dummy-3", "dummy-4", "dummy-5"}
+	for _, keyType := range keyTypes {
+		c.Assert(RegisterKeyConverter(keyType, converter(keyType), nil), IsNil)
+	}
+	for i := 0; i < 20; i++ {
+		pk, err := ToPublicKey(dummyPublicKey{pub}, nil)
+		c.Assert(err, IsNil)
+		c.Assert(pk.Type, Equals, "dummy-1")
+	}
+
+	// Registering a key type again keeps its place.
+	c.Assert(RegisterKeyConverter("dummy-1", converter("dummy-1-again"), nil), IsNil)
+	pk, err := ToPublicKey(dummyPublicKey{pub}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(pk.Type, Equals, "dummy-1-again")
+}
@@ -0,0 +1,252 @@
+package keys
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"golang.org/x/crypto/ssh"
+)
+
+// KeyInfo is a summary of a key, as returned by Inspect. It only holds public
+// information, even for private keys.
+type KeyInfo struct {
+	Type   string
+	Scheme string
+
+	// Bits is the size of the key: the modulus size for RSA keys, the
+	// curve size for ECDSA keys and 256 for ed25519 keys.
+	Bits int
+
+	// Curve is the name of the curve of ECDSA keys, and is empty for other
+	// key types.
+	Curve string
+
+	KeyIDs []string
+
+	// HasPrivate reports whether the inspected input holds private key
+	// material.
+	HasPrivate bool
+
+	// Fingerprint is the hex SHA-256 of the PKIX DER encoding of the public
+	// key, as computed from "openssl pkey -pubout -outform DER". It is empty
+	// for keys without a PKIX encoding.
+	Fingerprint string
+}
+
+// Inspect parses a key in any of the formats supported by this package and
+// returns a summary of it, for display to users. The format is detected from
+// the input, which may be:
+//
+//   - a public or private key object as used by this package, or a public
+//     key object of python's securesystemslib;
+//   - a PEM encoded PKIX public key, or PKCS #8, PKCS #1, SEC 1 or OpenSSH
+//     private key;
+//   - an SSH public key in authorized_keys format.
+//
+// Keys converted from other formats get the key type and scheme this package
+// would give them, such as rsassa-pss-sha256 for RSA keys. Encrypted private
+// keys are not supported.
+func Inspect(raw []byte) (*KeyInfo, error) {
+	raw = bytes.TrimSpace(raw)
+	if bytes.HasPrefix(raw, []byte("{")) {
+		pk, verifier, hasPrivate, err := parseJSONKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		info := inspectPublicKey(pk, cryptoPublicKey(verifier))
+		info.HasPrivate = hasPrivate
+		return info, nil
+	}
+	pub, hasPrivate, err := parseCryptoKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	return inspectCrypto(pub, hasPrivate)
+}
+
+// ParsePublicKey parses a key in any of the formats supported by Inspect and
+// returns its public key. Private keys are accepted, and only their public
+// part is returned. Public key objects are returned as found, so that the
+// PEM encoded ECDSA key values of securesystemslib keep their key IDs, while
+// keys converted from other formats get the key value of this package, and
+// thus its key IDs.
+func ParsePublicKey(raw []byte) (*data.PublicKey, error) {
+	raw = bytes.TrimSpace(raw)
+	if bytes.HasPrefix(raw, []byte("{")) {
+		_, verifier, _, err := parseJSONKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		return verifier.MarshalPublicKey(), nil
+	}
+	pub, _, err := parseCryptoKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	return publicKeyFromCrypto(pub)
+}
+
+// parseJSONKey parses a public or private key object, returning the public
+// key object as found in raw, a Verifier for it and whether raw holds a
+// private key.
+func parseJSONKey(raw []byte) (*data.PublicKey, Verifier, bool, error) {
+	var key struct {
+		Value struct {
+			Private json.RawMessage `json:"private"`
+		} `json:"keyval"`
+	}
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return nil, nil, false, err
+	}
+
+	var pk *data.PublicKey
+	hasPrivate := len(key.Value.Private) != 0
+	if hasPrivate {
+		priv := &data.PrivateKey{}
+		if err := json.Unmarshal(raw, priv); err != nil {
+			return nil, nil, false, err
+		}
+		signer, err := GetSigner(priv)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		pk = signer.PublicData()
+	} else {
+		pk = &data.PublicKey{}
+		if err := json.Unmarshal(raw, pk); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	verifier, err := GetVerifier(pk)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return pk, verifier, hasPrivate, nil
+}
+
+// parseCryptoKey parses a PEM encoded key or an SSH public key, returning its
+// public key and whether raw holds a private key.
+func parseCryptoKey(raw []byte) (crypto.PublicKey, bool, error) {
+	if bytes.HasPrefix(raw, []byte("-----BEGIN ")) {
+		return parsePEMKey(raw)
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: unknown key format", ErrInvalidArgument)
+	}
+	cpk, ok := pub.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, false, ErrUnsupportedKeyType
+	}
+	return cpk.CryptoPublicKey(), false, nil
+}
+
+func parsePEMKey(raw []byte) (crypto.PublicKey, bool, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, false, fmt.Errorf("%w: invalid PEM data", ErrInvalidArgument)
+	}
+	var (
+		priv crypto.PrivateKey
+		err  error
+	)
+	switch block.Type {
+	case "PUBLIC KEY", "RSA PUBLIC KEY":
+		// This package writes PKIX keys with the "RSA PUBLIC KEY" type.
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil && block.Type == "RSA PUBLIC KEY" {
+			pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return pub, false, nil
+	case "PRIVATE KEY":
+		priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		priv, err = x509.ParseECPrivateKey(block.Bytes)
+	case "OPENSSH PRIVATE KEY":
+		priv, err = ssh.ParseRawPrivateKey(raw)
+		if k, ok := priv.(*ed25519.PrivateKey); ok {
+			priv = *k
+		}
+	case "ENCRYPTED PRIVATE KEY":
+		return nil, false, fmt.Errorf("%w: encrypted private keys are not supported", ErrInvalidArgument)
+	default:
+		return nil, false, fmt.Errorf("%w: PEM type %q", ErrUnsupportedKeyType, block.Type)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	s, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, false, ErrUnsupportedKeyType
+	}
+	return s.Public(), true, nil
+}
+
+func inspectCrypto(pub crypto.PublicKey, hasPrivate bool) (*KeyInfo, error) {
+	pk, err := publicKeyFromCrypto(pub)
+	if err != nil {
+		return nil, err
+	}
+	info := inspectPublicKey(pk, pub)
+	info.HasPrivate = hasPrivate
+	return info, nil
+}
+
+// inspectPublicKey summarizes pk, whose standard library form is pub, or nil
+// for custom key types.
+func inspectPublicKey(pk *data.PublicKey, pub crypto.PublicKey) *KeyInfo {
+	info := &KeyInfo{
+		Type:   pk.Type,
+		Scheme: pk.Scheme,
+		KeyIDs: pk.IDs(),
+	}
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		info.Bits = 256
+	case *ecdsa.PublicKey:
+		info.Bits = k.Curve.Params().BitSize
+		info.Curve = k.Curve.Params().Name
+	case *rsa.PublicKey:
+		info.Bits = k.N.BitLen()
+	}
+	if pub != nil {
+		if der, err := x509.MarshalPKIXPublicKey(pub); err == nil {
+			sum := sha256.Sum256(der)
+			info.Fingerprint = hex.EncodeToString(sum[:])
+		}
+	}
+	return info
+}
+
+// cryptoPublicKey returns the standard library form of the key of v, or nil
+// for verifiers of custom key types.
+func cryptoPublicKey(v Verifier) crypto.PublicKey {
+	switch k := v.(type) {
+	case *ed25519Verifier:
+		return ed25519.PublicKey(k.PublicKey)
+	case *ecdsaVerifier:
+		x, y := unmarshalEcdsaPoint(k.params.curve, k.PublicKey)
+		if x == nil {
+			return nil
+		}
+		return &ecdsa.PublicKey{Curve: k.params.curve, X: x, Y: y}
+	case *rsaVerifier:
+		return k.rsaKey
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package keys
+
+import (
+	"time"
+)
+
+// An Observer receives a notification for every signing operation performed
+// by a Signer wrapped with InstrumentSigner. It can be implemented on top of
+// any metrics library to record signing counts, errors and latencies.
+type Observer interface {
+	// ObserveSign is called after each signature with the key scheme of the
+	// signer, the time spent signing and the error returned, if any.
+	ObserveSign(alg string, d time.Duration, err error)
+}
+
+// InstrumentSigner returns a Signer that reports every call to SignMessage
+// to obs.
+func InstrumentSigner(s Signer, obs Observer) Signer {
+	return &instrumentedSigner{Signer: s, obs: obs}
+}
+
+type instrumentedSigner struct {
+	Signer
+	obs Observer
+}
+
+func (s *instrumentedSigner) SignMessage(message []byte) ([]byte, error) {
+	start := time.Now()
+	sig, err := s.Signer.SignMessage(message)
+	s.obs.ObserveSign(signerAlgorithm(s.Signer), time.Since(start), err)
+	return sig, err
+}
+
+func (s *instrumentedSigner) digest(message []byte) ([]byte, error) {
+	return signerDigest(s.Signer, message)
+}
+
+func (s *instrumentedSigner) deterministic() bool {
+	return Deterministic(s.Signer)
+}
+
+// signerAlgorithm returns the key scheme of s, falling back to the key type
+// for keys without a scheme.
+func signerAlgorithm(s Signer) string {
+	pub := s.PublicData()
+	if pub.Scheme != "" {
+		return pub.Scheme
+	}
+	return pub.Type
+}
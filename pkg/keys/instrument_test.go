@@ -0,0 +1,76 @@
+package keys
+
+import (
+	"errors"
+	"time"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type InstrumentSuite struct{}
+
+var _ = Suite(&InstrumentSuite{})
+
+type observation struct {
+	alg string
+	d   time.Duration
+	err error
+}
+
+type recordingObserver struct {
+	observations []observation
+}
+
+func (r *recordingObserver) ObserveSign(alg string, d time.Duration, err error) {
+	r.observations = append(r.observations, observation{alg, d, err})
+}
+
+// slowSigner wraps a Signer, sleeping before each signature and optionally
+// failing.
+type slowSigner struct {
+	Signer
+	delay time.Duration
+	err   error
+}
+
+func (s *slowSigner) SignMessage(message []byte) ([]byte, error) {
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.Signer.SignMessage(message)
+}
+
+func (InstrumentSuite) TestInstrumentSigner(c *C) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	obs := &recordingObserver{}
+	signer := InstrumentSigner(&slowSigner{Signer: key, delay: 10 * time.Millisecond}, obs)
+
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, sig), IsNil)
+
+	c.Assert(obs.observations, HasLen, 1)
+	c.Assert(obs.observations[0].alg, Equals, data.KeySchemeEd25519)
+	c.Assert(obs.observations[0].d >= 10*time.Millisecond, Equals, true)
+	c.Assert(obs.observations[0].err, IsNil)
+}
+
+func (InstrumentSuite) TestInstrumentSignerError(c *C) {
+	key, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	obs := &recordingObserver{}
+	signErr := errors.New("sign failed")
+	signer := InstrumentSigner(&slowSigner{Signer: key, err: signErr}, obs)
+
+	_, err = signer.SignMessage([]byte("foo"))
+	c.Assert(err, Equals, signErr)
+	c.Assert(obs.observations, HasLen, 1)
+	c.Assert(obs.observations[0].alg, Equals, data.KeySchemeECDSA_SHA2_P256)
+	c.Assert(obs.observations[0].err, Equals, signErr)
+}
@@ -0,0 +1,166 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// JCSCanonicalizer selects RFC 8785 canonicalization, e.g. for key IDs with
+// data.PublicKey.IDsWith.
+var JCSCanonicalizer data.Canonicalizer = data.CanonicalizerFunc(JCSCanonicalize)
+
+// JCSCanonicalize encodes v as JSON and canonicalizes it following the JSON
+// Canonicalization Scheme (RFC 8785): object members are sorted by the
+// UTF-16 code units of their names, numbers are formatted as in ECMAScript
+// and strings use the minimal escaping.
+func JCSCanonicalize(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := jcsEncode(&buf, generic); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func jcsEncode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return fmt.Errorf("tuf: jcs: invalid number %s: %w", v, err)
+		}
+		s, err := jcsFormatNumber(f)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case string:
+		jcsEncodeString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := jcsEncode(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return jcsLess(names[i], names[j])
+		})
+		buf.WriteByte('{')
+		for i, name := range names {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			jcsEncodeString(buf, name)
+			buf.WriteByte(':')
+			if err := jcsEncode(buf, v[name]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("tuf: jcs: unsupported type %T", v)
+	}
+	return nil
+}
+
+// jcsLess compares strings by their UTF-16 code units.
+func jcsLess(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// jcsFormatNumber formats f like the ECMAScript Number.prototype.toString
+// method, as required by RFC 8785 section 3.2.2.3.
+func jcsFormatNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("tuf: jcs: invalid number %v", f)
+	}
+	if f == 0 {
+		// Also covers negative zero.
+		return "0", nil
+	}
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+	format := byte('e')
+	if f >= 1e-6 && f < 1e21 {
+		format = 'f'
+	}
+	s := strconv.FormatFloat(f, format, -1, 64)
+	// Go pads the exponent to two digits ("1e+09"), ECMAScript does not.
+	if i := strings.IndexByte(s, 'e'); i > 0 && s[i+2] == '0' {
+		s = s[:i+2] + s[i+3:]
+	}
+	return sign + s, nil
+}
+
+func jcsEncodeString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '"' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r == '\b':
+			buf.WriteString(`\b`)
+		case r == '\f':
+			buf.WriteString(`\f`)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\r':
+			buf.WriteString(`\r`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r < 0x20:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[r>>4])
+			buf.WriteByte(hex[r&0xf])
+		default:
+			buf.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,144 @@
+package keys
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type JCSSuite struct{}
+
+var _ = Suite(&JCSSuite{})
+
+// Test vectors from the RFC 8785 reference implementation
+// (https://github.com/cyberphone/json-canonicalization/tree/master/testdata).
+var jcsVectors = []struct {
+	name   string
+	input  string
+	output string
+}{
+	{
+		name:   "arrays",
+		input:  `[56, {"d": true, "10": null, "1": [ ]}]`,
+		output: `[56,{"1":[],"10":null,"d":true}]`,
+	},
+	{
+		name: "french",
+		input: `{
+			"peach": "This sorting order",
+			"péché": "is wrong according to French",
+			"pêche": "but canonicalization MUST",
+			"sin":   "ignore locale"
+		}`,
+		output: `{"peach":"This sorting order","péché":"is wrong according to French","pêche":"but canonicalization MUST","sin":"ignore locale"}`,
+	},
+	{
+		name: "structures",
+		input: `{
+			"1": {"f": {"f": "hi","F": 5} ,"\n": 56.0},
+			"10": { },
+			"": "empty",
+			"a": { },
+			"111": [ {"e": "yes","E": "no" } ],
+			"A": { }
+		}`,
+		output: `{"":"empty","1":{"\n":56,"f":{"F":5,"f":"hi"}},"10":{},"111":[{"E":"no","e":"yes"}],"A":{},"a":{}}`,
+	},
+	{
+		name: "unicode",
+		input: `{
+			"Unnormalized Unicode":"A\u030a"
+		}`,
+		output: "{\"Unnormalized Unicode\":\"A\u030a\"}",
+	},
+	{
+		name: "values",
+		input: `{
+			"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+			"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+			"literals": [null, true, false]
+		}`,
+		output: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+	},
+	{
+		name: "weird",
+		input: `{
+			"\u20ac": "Euro Sign",
+			"\r": "Carriage Return",
+			"\ufb33": "Hebrew Letter Dalet With Dagesh",
+			"1": "One",
+			"\ud83d\ude00": "Emoji: Grinning Face",
+			"\u0080": "Control",
+			"\u00f6": "Latin Small Letter O With Diaeresis"
+		}`,
+		output: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+	},
+}
+
+func (JCSSuite) TestVectors(c *C) {
+	for _, v := range jcsVectors {
+		out, err := JCSCanonicalize(json.RawMessage(v.input))
+		c.Assert(err, IsNil, Commentf("vector = %s", v.name))
+		c.Assert(string(out), Equals, v.output, Commentf("vector = %s", v.name))
+	}
+}
+
+// Number formatting vectors from the reference implementation, given as
+// IEEE 754 bit patterns.
+func (JCSSuite) TestNumbers(c *C) {
+	for _, v := range []struct {
+		bits uint64
+		want string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x4415af1d78b58c40, "100000000000000000000"},
+	} {
+		got, err := jcsFormatNumber(math.Float64frombits(v.bits))
+		c.Assert(err, IsNil)
+		c.Assert(got, Equals, v.want, Commentf("bits = %016x", v.bits))
+	}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := jcsFormatNumber(f)
+		c.Assert(err, NotNil)
+	}
+}
+
+func (JCSSuite) TestKeyIDs(c *C) {
+	signer, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	pk := signer.PublicData()
+
+	// The PEM encoded RSA key contains newlines, which JCS escapes and the
+	// default canonicalizer does not.
+	ids, err := pk.IDsWith(JCSCanonicalizer)
+	c.Assert(err, IsNil)
+	c.Assert(ids, HasLen, 1)
+	c.Assert(ids, Not(DeepEquals), pk.IDs())
+
+	// Hex encoded keys canonicalize the same way under both.
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ids, err = ed.PublicData().IDsWith(JCSCanonicalizer)
+	c.Assert(err, IsNil)
+	c.Assert(ids, DeepEquals, ed.PublicData().IDs())
+
+	defaultIDs, err := ed.PublicData().IDsWith(data.DefaultCanonicalizer)
+	c.Assert(err, IsNil)
+	c.Assert(defaultIDs, DeepEquals, ed.PublicData().IDs())
+}
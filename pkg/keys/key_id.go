@@ -0,0 +1,75 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrStoredKeyIDMismatch is returned when the key ID stored with a private
+// key is not the ID of that key.
+var ErrStoredKeyIDMismatch = errors.New("tuf: stored key id does not match the key")
+
+// MarshalPrivateKeyWithID returns the private key data of s like
+// s.MarshalPrivateKey, with the key ID recorded in it.
+func MarshalPrivateKeyWithID(s Signer) (*data.PrivateKey, error) {
+	pk, err := s.MarshalPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	pk.KeyID = s.PublicData().IDs()[0]
+	return pk, nil
+}
+
+// checkStoredKeyID checks that the key ID stored in key, if any, is the ID
+// of the public key of s.
+func checkStoredKeyID(key *data.PrivateKey, s Signer) error {
+	if key.KeyID == "" || s.PublicData().ContainsID(key.KeyID) {
+		return nil
+	}
+	return ErrStoredKeyIDMismatch
+}
+
+// KeyIDsForKeys computes the key IDs of every key of keys, a map from key ID
+// to key as found in root metadata, and checks that each key is listed under
+// one of its own IDs. The keys are canonicalized with
+// data.DefaultCanonicalizer, as with data.PublicKey.IDs, but errors are
+// returned rather than panicking, so that untrusted metadata can be checked.
+// The returned map holds the IDs of each key under its map key.
+func KeyIDsForKeys(keys map[string]*data.PublicKey) (map[string][]string, error) {
+	// Check the keys in a fixed order, so that errors are reproducible.
+	listed := make([]string, 0, len(keys))
+	for id := range keys {
+		listed = append(listed, id)
+	}
+	sort.Strings(listed)
+
+	c := data.DefaultCanonicalizer
+	ids := make(map[string][]string, len(keys))
+	for _, id := range listed {
+		k := keys[id]
+		if k == nil {
+			return nil, fmt.Errorf("%w: no key for key ID %s", ErrInvalidKey, id)
+		}
+		computed, err := k.IDsWith(c)
+		if err != nil {
+			return nil, err
+		}
+		if !containsKeyID(computed, id) {
+			return nil, fmt.Errorf("%w: key ID %s is not an ID of its key", ErrInvalidKey, id)
+		}
+		ids[id] = computed
+	}
+	return ids, nil
+}
+
+func containsKeyID(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
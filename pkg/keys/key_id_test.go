@@ -0,0 +1,123 @@
+package keys
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type KeyIDSuite struct{}
+
+var _ = Suite(&KeyIDSuite{})
+
+func (KeyIDSuite) TestStoredKeyID(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pk, err := MarshalPrivateKeyWithID(signer)
+	c.Assert(err, IsNil)
+	c.Assert(pk.KeyID, Equals, signer.PublicData().IDs()[0])
+
+	// The key ID survives a JSON round trip.
+	b, err := json.Marshal(pk)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(b), `"keyid":"`+pk.KeyID+`"`), Equals, true)
+
+	s, err := GetSigner(pk)
+	c.Assert(err, IsNil)
+	c.Assert(s.PublicData().IDs()[0], Equals, pk.KeyID)
+
+	// Keys without a stored key ID load as before.
+	pk.KeyID = ""
+	_, err = GetSigner(pk)
+	c.Assert(err, IsNil)
+}
+
+func (KeyIDSuite) TestTamperedStoredKeyID(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	other, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	pk, err := MarshalPrivateKeyWithID(signer)
+	c.Assert(err, IsNil)
+	pk.KeyID = other.PublicData().IDs()[0]
+
+	_, err = GetSigner(pk)
+	c.Assert(err, Equals, ErrStoredKeyIDMismatch)
+	_, err = GetSignerWithOptions(pk, nil)
+	c.Assert(err, Equals, ErrStoredKeyIDMismatch)
+
+	// The check is part of the consistency check.
+	_, err = GetSignerWithOptions(pk, &SignerOptions{SkipConsistencyCheck: true})
+	c.Assert(err, IsNil)
+}
+
+// rootKeys returns n keys, alternating key types, listed by their key IDs
+// like in root metadata.
+func rootKeys(n int) (map[string]*data.PublicKey, error) {
+	keys := make(map[string]*data.PublicKey, n)
+	for i := 0; i < n; i++ {
+		var (
+			signer Signer
+			err    error
+		)
+		if i%2 == 0 {
+			signer, err = GenerateEd25519Key()
+		} else {
+			signer, err = GenerateEcdsaKey()
+		}
+		if err != nil {
+			return nil, err
+		}
+		pk := signer.PublicData()
+		keys[pk.IDs()[0]] = pk
+	}
+	return keys, nil
+}
+
+func (KeyIDSuite) TestKeyIDsForKeys(c *C) {
+	keys, err := rootKeys(20)
+	c.Assert(err, IsNil)
+	ids, err := KeyIDsForKeys(keys)
+	c.Assert(err, IsNil)
+	c.Assert(ids, HasLen, 20)
+	for id, pk := range keys {
+		c.Assert(ids[id], DeepEquals, pk.IDs())
+	}
+
+	// A key listed under the ID of another key.
+	var first, second string
+	for id := range keys {
+		if first == "" {
+			first = id
+		} else if second == "" {
+			second = id
+		}
+	}
+	keys[first], keys[second] = keys[second], keys[first]
+	_, err = KeyIDsForKeys(keys)
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+
+	_, err = KeyIDsForKeys(map[string]*data.PublicKey{"foo": nil})
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+	ids, err = KeyIDsForKeys(nil)
+	c.Assert(err, IsNil)
+	c.Assert(ids, HasLen, 0)
+}
+
+func BenchmarkKeyIDsForKeys(b *testing.B) {
+	keys, err := rootKeys(20)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := KeyIDsForKeys(keys); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
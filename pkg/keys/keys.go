@@ -3,6 +3,7 @@ package keys
 import (
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/theupdateframework/go-tuf/data"
@@ -15,11 +16,24 @@ var SignerMap sync.Map
 var VerifierMap sync.Map
 
 var (
-	ErrInvalid    = errors.New("tuf: signature verification failed")
-	ErrInvalidKey = errors.New("invalid key")
+	ErrInvalid            = errors.New("tuf: signature verification failed")
+	ErrInvalidKey         = errors.New("invalid key")
+	ErrUnsupportedKeyType = errors.New("tuf: unsupported key type")
+	ErrInvalidArgument    = errors.New("tuf: invalid argument")
+	ErrUnsupportedCurve   = errors.New("tuf: unsupported ecdsa curve")
+	ErrKeyMismatch        = errors.New("tuf: key does not match its declared type")
+	ErrMalformedKey       = errors.New("tuf: malformed key")
+
+	// ErrMissingPublicKey is returned for key values without a "public"
+	// field, typically because of a misspelled field name.
+	ErrMissingPublicKey = errors.New(`tuf: key value has no "public" field`)
 )
 
 // A Verifier verifies public key signatures.
+//
+// The verifiers of this package are safe for concurrent use, and
+// implementations registered for other key types must be too, as verifiers
+// may be shared between goroutines, for example by VerifyParallel.
 type Verifier interface {
 	// UnmarshalPublicKey takes key data to a working verifier implementation for the key type.
 	// This performs any validation over the data.PublicKey to ensure that the verifier is usable
@@ -38,6 +52,56 @@ type Verifier interface {
 	Verify(msg, sig []byte) error
 }
 
+// A StreamVerifier is a Verifier that can verify a signature over a message
+// read from an io.Reader, without holding the whole message in memory.
+type StreamVerifier interface {
+	Verifier
+
+	// VerifyReader reads the message from r until EOF and determines
+	// whether the signature is valid for the given key and message.
+	VerifyReader(r io.Reader, sig []byte) error
+}
+
+// A StreamSigner is a Signer that can sign a message read from an io.Reader,
+// without holding the whole message in memory.
+type StreamSigner interface {
+	Signer
+
+	// SignReader reads the message from r until EOF and returns its
+	// signature.
+	SignReader(r io.Reader) ([]byte, error)
+}
+
+// A BatchSigner is a Signer that can sign several messages at once, such as a
+// signer backed by a network service or an HSM that pipelines the requests
+// in a single round trip.
+type BatchSigner interface {
+	Signer
+
+	// SignBatch returns the signatures of msgs, in the same order.
+	SignBatch(msgs [][]byte) ([][]byte, error)
+}
+
+// A DigestSigner is a Signer that can sign a precomputed message digest.
+type DigestSigner interface {
+	Signer
+
+	// SignDigest returns the signature of a message given its digest.
+	SignDigest(digest []byte) ([]byte, error)
+}
+
+// A DigestVerifier is a Verifier that can verify a signature given the
+// digest of the message.
+type DigestVerifier interface {
+	Verifier
+
+	// VerifyDigest determines whether the signature is valid for the given
+	// key and message digest.
+	VerifyDigest(digest, sig []byte) error
+}
+
+// A Signer signs messages with a private key. Once unmarshalled, the signers
+// of this package are safe for concurrent use.
 type Signer interface {
 	// MarshalPrivateKey returns the private key data.
 	MarshalPrivateKey() (*data.PrivateKey, error)
@@ -54,11 +118,17 @@ type Signer interface {
 	SignMessage(message []byte) ([]byte, error)
 }
 
+// GetVerifier returns the Verifier for key, as registered in VerifierMap for
+// its key type. Key values with a field appearing more than once fail with
+// ErrMalformedKey.
 func GetVerifier(key *data.PublicKey) (Verifier, error) {
 	st, ok := VerifierMap.Load(key.Type)
 	if !ok {
 		return nil, ErrInvalidKey
 	}
+	if err := checkDuplicateFields(key.Value); err != nil {
+		return nil, err
+	}
 	s := st.(func() Verifier)()
 	if err := s.UnmarshalPublicKey(key); err != nil {
 		return nil, fmt.Errorf("tuf: error unmarshalling key: %w", err)
@@ -66,14 +136,23 @@ func GetVerifier(key *data.PublicKey) (Verifier, error) {
 	return s, nil
 }
 
+// GetSigner returns the Signer for key, as registered in SignerMap for its
+// key type. Key values with a field appearing more than once fail with
+// ErrMalformedKey.
 func GetSigner(key *data.PrivateKey) (Signer, error) {
 	st, ok := SignerMap.Load(key.Type)
 	if !ok {
 		return nil, ErrInvalidKey
 	}
+	if err := checkDuplicateFields(key.Value); err != nil {
+		return nil, err
+	}
 	s := st.(func() Signer)()
 	if err := s.UnmarshalPrivateKey(key); err != nil {
 		return nil, fmt.Errorf("tuf: error unmarshalling key: %w", err)
 	}
+	if err := checkStoredKeyID(key, s); err != nil {
+		return nil, err
+	}
 	return s, nil
 }
@@ -1,8 +1,11 @@
 package keys
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
+	"github.com/theupdateframework/go-tuf/data"
 	. "gopkg.in/check.v1"
 )
 
@@ -36,3 +39,79 @@ func (KeysSuite) TestSignerKeyIDs(c *C) {
 	err = signer.UnmarshalPrivateKey(privKey)
 	c.Assert(err, IsNil)
 }
+
+func (KeysSuite) TestMissingPublicKey(c *C) {
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ec, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+
+	for _, s := range []Signer{ed, ec, rsa} {
+		// The value is valid JSON, but the field is misspelled.
+		var value map[string]interface{}
+		c.Assert(json.Unmarshal(s.PublicData().Value, &value), IsNil)
+		value["pub"] = value["public"]
+		delete(value, "public")
+		b, err := json.Marshal(value)
+		c.Assert(err, IsNil)
+
+		pub := *s.PublicData()
+		pub.Value = b
+		_, err = GetVerifier(&pub)
+		c.Assert(errors.Is(err, ErrMissingPublicKey), Equals, true, Commentf("%s: %v", pub.Type, err))
+	}
+
+	for _, s := range []Signer{ed, ec} {
+		priv, err := s.MarshalPrivateKey()
+		c.Assert(err, IsNil)
+		var value map[string]interface{}
+		c.Assert(json.Unmarshal(priv.Value, &value), IsNil)
+		value["pub"] = value["public"]
+		delete(value, "public")
+		priv.Value, err = json.Marshal(value)
+		c.Assert(err, IsNil)
+		_, err = GetSigner(priv)
+		c.Assert(errors.Is(err, ErrMissingPublicKey), Equals, true, Commentf("%s: %v", priv.Type, err))
+	}
+
+	_, err = GetVerifier(&data.PublicKey{
+		Type:  data.KeyTypeEd25519,
+		Value: []byte(`{"pub":"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"}`),
+	})
+	c.Assert(errors.Is(err, ErrMissingPublicKey), Equals, true)
+}
+
+func (KeysSuite) TestDuplicateFields(c *C) {
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	other, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	public := mustPublicHex(c, ed.PublicData())
+	otherPublic := mustPublicHex(c, other.PublicData())
+
+	// encoding/json would read the last "public", python-tuf the first.
+	for _, value := range []string{
+		`{"public":"` + public + `","public":"` + otherPublic + `"}`,
+		`{"public":"` + public + `","Public":"` + otherPublic + `"}`,
+		`{"public":"` + public + `","extra":{"a":1,"a":2}}`,
+	} {
+		_, err = GetVerifier(&data.PublicKey{Type: data.KeyTypeEd25519, Scheme: data.KeySchemeEd25519, Value: []byte(value)})
+		c.Assert(errors.Is(err, ErrMalformedKey), Equals, true, Commentf("%s: %v", value, err))
+	}
+	_, err = GetVerifier(&data.PublicKey{Type: data.KeyTypeEd25519, Scheme: data.KeySchemeEd25519, Value: []byte(`{"public":"` + public + `","extra":[{"a":1},{"a":2}]}`)})
+	c.Assert(err, IsNil)
+
+	priv, err := ed.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	var value map[string]string
+	c.Assert(json.Unmarshal(priv.Value, &value), IsNil)
+	priv.Value = []byte(`{"public":"` + value["public"] + `","private":"` + value["private"] + `","public":"` + otherPublic + `"}`)
+	_, err = GetSigner(priv)
+	c.Assert(errors.Is(err, ErrMalformedKey), Equals, true)
+
+	// Duplicate fields of the key object itself.
+	_, _, err = FromTUFKey([]byte(`{"keytype":"ed25519","scheme":"ed25519","keyval":{"public":"` + public + `"},"keyval":{"public":"` + otherPublic + `"}}`))
+	c.Assert(errors.Is(err, ErrMalformedKey), Equals, true)
+}
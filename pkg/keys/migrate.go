@@ -0,0 +1,93 @@
+package keys
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// MigrateHexKey parses a public key object written by older or third-party
+// tooling and returns it in the current format of this package, whose
+// ed25519 and ECDSA key values are lower case hex strings. Public values in
+// upper or mixed case hex, with a "0x" prefix, or in standard or URL-safe
+// base64 are converted. Keys already in the current format, and RSA keys,
+// whose values are PEM, are returned as they are.
+//
+// Converting a key value changes its key IDs, so metadata referring to the
+// key by its old IDs has to be updated along with it.
+func MigrateHexKey(raw []byte) (*data.PublicKey, error) {
+	key := &data.PublicKey{}
+	if err := json.Unmarshal(raw, key); err != nil {
+		return nil, err
+	}
+	var value map[string]json.RawMessage
+	if err := json.Unmarshal(key.Value, &value); err != nil {
+		return nil, err
+	}
+	var public string
+	if err := json.Unmarshal(value["public"], &public); err != nil || public == "" {
+		return nil, ErrMissingPublicKey
+	}
+	if _, err := GetVerifier(key); err == nil {
+		// Hex values are accepted in any case, but the key IDs of the
+		// current format are computed from lower case ones.
+		b, err := hex.DecodeString(public)
+		if err != nil || public == hex.EncodeToString(b) {
+			return key, nil
+		}
+		return withPublicValue(key, value, b)
+	}
+
+	for _, decode := range []func(string) ([]byte, error){
+		func(s string) ([]byte, error) {
+			s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+			return hex.DecodeString(s)
+		},
+		base64.StdEncoding.DecodeString,
+		base64.RawStdEncoding.DecodeString,
+		base64.URLEncoding.DecodeString,
+		base64.RawURLEncoding.DecodeString,
+	} {
+		b, err := decode(public)
+		if err != nil {
+			continue
+		}
+		migrated, err := withPublicValue(key, value, b)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := GetVerifier(migrated); err == nil {
+			return migrated, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unrecognized %s public key encoding", ErrMalformedKey, key.Type)
+}
+
+// withPublicValue returns a copy of key with the public field of its value
+// set to the hex encoding of public, keeping the other fields of value.
+func withPublicValue(key *data.PublicKey, value map[string]json.RawMessage, public []byte) (*data.PublicKey, error) {
+	fields := make(map[string]json.RawMessage, len(value))
+	for k, v := range value {
+		fields[k] = v
+	}
+	encoded, err := json.Marshal(data.HexBytes(public))
+	if err != nil {
+		return nil, err
+	}
+	fields["public"] = encoded
+	valueBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &data.PublicKey{
+		Type:       key.Type,
+		Scheme:     key.Scheme,
+		Algorithms: key.Algorithms,
+		Value:      valueBytes,
+		Expires:    key.Expires,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type MigrateSuite struct{}
+
+var _ = Suite(&MigrateSuite{})
+
+func (MigrateSuite) TestMigrateHexKey(c *C) {
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	current := ed.PublicData()
+	public := []byte(ed.PrivateKey.Public().(ed25519.PublicKey))
+	keyObject := func(value string) []byte {
+		return []byte(fmt.Sprintf(`{"keytype":"ed25519","scheme":"ed25519","keyid_hash_algorithms":["sha256","sha512"],"keyval":{"public":%q}}`, value))
+	}
+
+	for _, value := range []string{
+		hex.EncodeToString(public),
+		strings.ToUpper(hex.EncodeToString(public)),
+		"0x" + hex.EncodeToString(public),
+		base64.StdEncoding.EncodeToString(public),
+		base64.RawURLEncoding.EncodeToString(public),
+	} {
+		key, err := MigrateHexKey(keyObject(value))
+		c.Assert(err, IsNil, Commentf("%s", value))
+		c.Assert(key.IDs(), DeepEquals, current.IDs(), Commentf("%s", value))
+		c.Assert(string(key.Value), Equals, string(current.Value))
+	}
+
+	_, err = MigrateHexKey(keyObject("not a key"))
+	c.Assert(errors.Is(err, ErrMalformedKey), Equals, true)
+	_, err = MigrateHexKey([]byte(`{"keytype":"ed25519","scheme":"ed25519","keyval":{"pub":"00"}}`))
+	c.Assert(errors.Is(err, ErrMissingPublicKey), Equals, true)
+}
+
+func (MigrateSuite) TestMigrateHexKeyOtherTypes(c *C) {
+	ec, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	var value struct {
+		Public data.HexBytes `json:"public"`
+	}
+	c.Assert(json.Unmarshal(ec.PublicData().Value, &value), IsNil)
+	b64 := []byte(fmt.Sprintf(`{"keytype":"ecdsa-sha2-nistp256","scheme":"ecdsa-sha2-nistp256","keyid_hash_algorithms":["sha256","sha512"],"keyval":{"public":%q}}`,
+		base64.StdEncoding.EncodeToString(value.Public)))
+	key, err := MigrateHexKey(b64)
+	c.Assert(err, IsNil)
+	c.Assert(key.IDs(), DeepEquals, ec.PublicData().IDs())
+
+	// RSA key values are PEM, and are kept.
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	raw, err := json.Marshal(rsa.PublicData())
+	c.Assert(err, IsNil)
+	key, err = MigrateHexKey(raw)
+	c.Assert(err, IsNil)
+	c.Assert(key.IDs(), DeepEquals, rsa.PublicData().IDs())
+}
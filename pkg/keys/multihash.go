@@ -0,0 +1,91 @@
+package keys
+
+import (
+	"crypto"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// multihashCodes maps the multihash codes of the hash functions keys of this
+// package sign with to these functions, from the multicodec table.
+var multihashCodes = map[uint64]crypto.Hash{
+	0x12: crypto.SHA256,
+	0x13: crypto.SHA512,
+	0x20: crypto.SHA384,
+	0x16: crypto.SHA3_256,
+	0x15: crypto.SHA3_384,
+	0x14: crypto.SHA3_512,
+}
+
+// VerifyMultihash verifies signature with pk over the content identified by
+// mh, a binary multihash such as found in IPFS CIDv1s, without the content:
+// the signature is verified over the digest mh embeds, which must have been
+// computed with the hash function of the key scheme. Multihashes of other
+// hash functions fail with ErrHashMismatch.
+//
+// ECDSA and RSA keys are supported. Pure ed25519 signatures are over the
+// full content rather than a digest, so ed25519 keys fail with
+// ErrPrehashNotSupported.
+func VerifyMultihash(mh []byte, signature []byte, pk *data.PublicKey) error {
+	hash, digest, err := parseMultihash(mh)
+	if err != nil {
+		return err
+	}
+	verifier, err := GetVerifier(pk)
+	if err != nil {
+		return err
+	}
+	keyHash, verifyDigest, err := digestVerifier(verifier)
+	if err != nil {
+		return err
+	}
+	if hash != keyHash {
+		return fmt.Errorf("%w: multihash is %v, %s keys sign %v digests", ErrHashMismatch, hash, pk.Type, keyHash)
+	}
+	return verifyDigest(digest, signature)
+}
+
+// parseMultihash returns the hash function and the digest of mh, a varint
+// hash function code followed by the varint length of the digest and the
+// digest. Only full length digests are accepted.
+func parseMultihash(mh []byte) (crypto.Hash, []byte, error) {
+	code, n := binary.Uvarint(mh)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("%w: invalid multihash code", ErrInvalidArgument)
+	}
+	mh = mh[n:]
+	length, n := binary.Uvarint(mh)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("%w: invalid multihash length", ErrInvalidArgument)
+	}
+	digest := mh[n:]
+	if uint64(len(digest)) != length {
+		return 0, nil, fmt.Errorf("%w: multihash digest is %d bytes, declared %d", ErrInvalidArgument, len(digest), length)
+	}
+	hash, ok := multihashCodes[code]
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: multihash code 0x%x", ErrHashMismatch, code)
+	}
+	if len(digest) != hash.Size() {
+		return 0, nil, fmt.Errorf("%w: truncated %v multihash digest", ErrInvalidArgument, hash)
+	}
+	return hash, digest, nil
+}
+
+// digestVerifier returns the hash function v applies to messages and a
+// function verifying signatures given the digest of the message.
+func digestVerifier(v Verifier) (crypto.Hash, func(digest, sig []byte) error, error) {
+	switch k := v.(type) {
+	case *ecdsaVerifier:
+		return k.params.hash, func(digest, sig []byte) error {
+			return k.verifyDigest(digest, sig, &VerifyOptions{})
+		}, nil
+	case *rsaVerifier:
+		return crypto.SHA256, k.verifyDigest, nil
+	case *ed25519Verifier:
+		return 0, nil, fmt.Errorf("%w: pure ed25519 signs the full message", ErrPrehashNotSupported)
+	}
+	return 0, nil, ErrPrehashNotSupported
+}
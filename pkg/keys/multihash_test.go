@@ -0,0 +1,95 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type MultihashSuite struct{}
+
+var _ = Suite(&MultihashSuite{})
+
+func (MultihashSuite) TestVerifyMultihash(c *C) {
+	content := []byte("hello world")
+	digest := sha256.Sum256(content)
+	mh := append([]byte{0x12, 0x20}, digest[:]...)
+
+	// A sha2-256 code and a 32 byte length, followed by the digest.
+	c.Assert(mh, DeepEquals, mustHex(c, "1220b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"))
+
+	for _, s := range []Signer{mustGenerateEcdsa(c, data.KeyTypeECDSA_SHA2_P256), mustGenerateRsa(c)} {
+		comment := Commentf("%s", s.PublicData().Type)
+		sig, err := s.SignMessage(content)
+		c.Assert(err, IsNil, comment)
+		c.Assert(VerifyMultihash(mh, sig, s.PublicData()), IsNil, comment)
+
+		other := sha256.Sum256([]byte("bar"))
+		c.Assert(VerifyMultihash(append([]byte{0x12, 0x20}, other[:]...), sig, s.PublicData()), NotNil, comment)
+	}
+}
+
+func (MultihashSuite) TestVerifyMultihashMismatchedHash(c *C) {
+	content := []byte("hello world")
+	sha256Digest := sha256.Sum256(content)
+	sha512Digest := sha512.Sum512(content)
+	sha256Multihash := append([]byte{0x12, 0x20}, sha256Digest[:]...)
+	// The length of SHA-512 digests is 64, a single byte varint.
+	sha512Multihash := append([]byte{0x13, 0x40}, sha512Digest[:]...)
+
+	p256 := mustGenerateEcdsa(c, data.KeyTypeECDSA_SHA2_P256)
+	sig, err := p256.SignMessage(content)
+	c.Assert(err, IsNil)
+	err = VerifyMultihash(sha512Multihash, sig, p256.PublicData())
+	c.Assert(errors.Is(err, ErrHashMismatch), Equals, true)
+
+	p521 := mustGenerateEcdsa(c, data.KeyTypeECDSA_SHA2_P521)
+	sig, err = p521.SignMessage(content)
+	c.Assert(err, IsNil)
+	c.Assert(VerifyMultihash(sha512Multihash, sig, p521.PublicData()), IsNil)
+	err = VerifyMultihash(sha256Multihash, sig, p521.PublicData())
+	c.Assert(errors.Is(err, ErrHashMismatch), Equals, true)
+
+	// Unknown hash functions, such as identity, never match.
+	err = VerifyMultihash(append([]byte{0x00, 0x0b}, content...), sig, p521.PublicData())
+	c.Assert(errors.Is(err, ErrHashMismatch), Equals, true)
+}
+
+func (MultihashSuite) TestVerifyMultihashInvalid(c *C) {
+	p256 := mustGenerateEcdsa(c, data.KeyTypeECDSA_SHA2_P256)
+	digest := sha256.Sum256([]byte("foo"))
+	sig, err := p256.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+
+	for _, mh := range [][]byte{
+		nil,
+		{0x12},
+		{0x12, 0x20},
+		append([]byte{0x12, 0x21}, digest[:]...),
+		append([]byte{0x12, 0x10}, digest[:16]...),
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	} {
+		err := VerifyMultihash(mh, sig, p256.PublicData())
+		c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true, Commentf("%x", mh))
+	}
+
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	err = VerifyMultihash(append([]byte{0x12, 0x20}, digest[:]...), sig, ed.PublicData())
+	c.Assert(errors.Is(err, ErrPrehashNotSupported), Equals, true)
+}
+
+func mustGenerateEcdsa(c *C, keyType string) Signer {
+	s, err := GenerateEcdsaKeyWithType(keyType)
+	c.Assert(err, IsNil)
+	return s
+}
+
+func mustGenerateRsa(c *C) Signer {
+	s, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	return s
+}
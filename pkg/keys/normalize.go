@@ -0,0 +1,72 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// keyNormalizer is implemented by verifiers able to re-encode their public
+// key value in canonical form.
+type keyNormalizer interface {
+	normalizedValue() (json.RawMessage, error)
+}
+
+// NormalizeKey returns a copy of pk with its key value re-encoded in the
+// canonical form used by the signers of this package: lowercase hex for
+// ed25519 keys, uncompressed SEC1 points for ECDSA keys and PEM encoded PKIX
+// for RSA keys. Unknown fields in the key value are dropped, while the
+// expiry of pk is kept.
+//
+// Since key IDs are computed over the encoded key, normalizing a key that
+// was not in canonical form changes its key ID. This happens at most once:
+// NormalizeKey is idempotent, so the key ID of a normalized key is stable.
+//
+// ECDSA keys with compressed points, which GetVerifier rejects, are accepted
+// so that they can be migrated.
+func NormalizeKey(pk *data.PublicKey) (*data.PublicKey, error) {
+	verifier, err := normalizationVerifier(pk)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := verifier.(keyNormalizer)
+	if !ok {
+		return nil, fmt.Errorf("tuf: normalizing %s keys is not supported", pk.Type)
+	}
+	value, err := n.normalizedValue()
+	if err != nil {
+		return nil, err
+	}
+	normalized := &data.PublicKey{
+		Type:       pk.Type,
+		Scheme:     pk.Scheme,
+		Algorithms: append([]string(nil), pk.Algorithms...),
+		Value:      value,
+	}
+	if pk.Expires != nil {
+		expires := *pk.Expires
+		normalized.Expires = &expires
+	}
+	return normalized, nil
+}
+
+// normalizationVerifier returns the Verifier for pk like GetVerifier, except
+// that ECDSA keys may hold compressed points.
+func normalizationVerifier(pk *data.PublicKey) (Verifier, error) {
+	st, ok := VerifierMap.Load(pk.Type)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	v, ok := st.(func() Verifier)().(*ecdsaVerifier)
+	if !ok {
+		return GetVerifier(pk)
+	}
+	if err := checkDuplicateFields(pk.Value); err != nil {
+		return nil, err
+	}
+	if err := v.unmarshalPublicKey(pk, true); err != nil {
+		return nil, fmt.Errorf("tuf: error unmarshalling key: %w", err)
+	}
+	return v, nil
+}
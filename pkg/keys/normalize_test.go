@@ -0,0 +1,112 @@
+package keys
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type NormalizeSuite struct{}
+
+var _ = Suite(&NormalizeSuite{})
+
+func (NormalizeSuite) TestNormalizeCanonicalKeys(c *C) {
+	ed25519Key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ecdsaKey, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsaKey, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+
+	for _, signer := range []Signer{ed25519Key, ecdsaKey, rsaKey} {
+		pub := signer.PublicData()
+		normalized, err := NormalizeKey(pub)
+		c.Assert(err, IsNil)
+		// Keys generated by this package are already canonical.
+		c.Assert(normalized.IDs(), DeepEquals, pub.IDs())
+
+		again, err := NormalizeKey(normalized)
+		c.Assert(err, IsNil)
+		c.Assert(again.Value, DeepEquals, normalized.Value)
+	}
+}
+
+func (NormalizeSuite) TestNormalizeCompressedEcdsaKey(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	value, err := json.Marshal(ecdsaVerifier{PublicKey: CompressEcdsaPoint(&signer.PublicKey)})
+	c.Assert(err, IsNil)
+	compressed := &data.PublicKey{
+		Type:       signer.PublicData().Type,
+		Scheme:     signer.PublicData().Scheme,
+		Algorithms: data.HashAlgorithms,
+		Value:      value,
+	}
+
+	normalized, err := NormalizeKey(compressed)
+	c.Assert(err, IsNil)
+	c.Assert(normalized.IDs(), Not(DeepEquals), compressed.IDs())
+	c.Assert(normalized.IDs(), DeepEquals, signer.PublicData().IDs())
+
+	// The normalized key still verifies signatures of the original key.
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(normalized)
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, sig), IsNil)
+}
+
+func (NormalizeSuite) TestNormalizeEd25519UppercaseHex(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pub := signer.PublicData()
+	var value map[string]string
+	c.Assert(json.Unmarshal(pub.Value, &value), IsNil)
+	upper, err := json.Marshal(map[string]string{"public": strings.ToUpper(value["public"]), "extra": "dropped"})
+	c.Assert(err, IsNil)
+
+	normalized, err := NormalizeKey(&data.PublicKey{Type: pub.Type, Scheme: pub.Scheme, Algorithms: pub.Algorithms, Value: upper})
+	c.Assert(err, IsNil)
+	c.Assert(normalized.IDs(), DeepEquals, pub.IDs())
+}
+
+func (NormalizeSuite) TestNormalizeKeepsExpiry(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pub := signer.PublicData()
+	var value map[string]string
+	c.Assert(json.Unmarshal(pub.Value, &value), IsNil)
+	upper, err := json.Marshal(map[string]string{"public": strings.ToUpper(value["public"])})
+	c.Assert(err, IsNil)
+
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiring := &data.PublicKey{Type: pub.Type, Scheme: pub.Scheme, Algorithms: pub.Algorithms, Value: upper, Expires: &expires}
+	normalized, err := NormalizeKey(expiring)
+	c.Assert(err, IsNil)
+	c.Assert(normalized.Expires, NotNil)
+	c.Assert(normalized.Expires.Equal(expires), Equals, true)
+
+	// The expiry is part of the new key ID.
+	withExpiry := &data.PublicKey{Type: pub.Type, Scheme: pub.Scheme, Algorithms: pub.Algorithms, Value: pub.Value, Expires: &expires}
+	c.Assert(normalized.IDs(), DeepEquals, withExpiry.IDs())
+	c.Assert(normalized.IDs(), Not(DeepEquals), pub.IDs())
+}
+
+func (NormalizeSuite) TestNormalizePKCS1RsaKey(c *C) {
+	signer, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&signer.PublicKey)})
+	value, err := json.Marshal(rsaPublic{PublicKey: string(pkcs1)})
+	c.Assert(err, IsNil)
+	pub := signer.PublicData()
+
+	normalized, err := NormalizeKey(&data.PublicKey{Type: pub.Type, Scheme: pub.Scheme, Algorithms: pub.Algorithms, Value: value})
+	c.Assert(err, IsNil)
+	c.Assert(normalized.IDs(), DeepEquals, pub.IDs())
+}
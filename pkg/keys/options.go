@@ -0,0 +1,151 @@
+package keys
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// VerifyOptions holds optional settings for VerifyWithOptions. The zero value
+// gives the same behaviour as Verifier.Verify.
+type VerifyOptions struct {
+	// AutoSignatureFormat makes ECDSA verifiers accept raw r||s (IEEE P1363)
+	// signatures in addition to ASN.1 DER ones. A signature whose length is
+	// exactly twice the curve size is parsed as raw, any other as DER. This
+	// eases migrating stored signatures from one format to the other.
+	AutoSignatureFormat bool
+
+	// LenientSignatureLength makes ECDSA verifiers accept raw r||s
+	// signatures whose values were not left-padded to the curve size, as
+	// emitted by some older Java signers, making them shorter than twice the
+	// curve size. Such a signature is only tried if it is not valid DER; as
+	// the boundary between r and s is unknown, each possible split is
+	// verified in turn, which costs up to one verification per byte of the
+	// curve size for invalid signatures.
+	LenientSignatureLength bool
+
+	// Hash overrides the hash function applied to the message before
+	// verifying an ECDSA signature, for signers that do not use the hash
+	// of the key scheme, such as WebCrypto clients hashing with SHA-384
+	// on a P-256 key. The zero value uses the hash of the key scheme.
+	//
+	// ECDSA signatures do not record the hash they were made with, and the
+	// digest is truncated to the size of the curve, so a signature made with
+	// another hash merely fails to verify: set ExpectedHash to have such a
+	// mismatch reported as such.
+	Hash crypto.Hash
+
+	// ExpectedHash, if set, is the hash function the signature is known to
+	// have been made with, for example as recorded next to the signature by
+	// the application. ECDSA verification fails with ErrHashMismatch if the
+	// hash in use, Hash or the hash of the key scheme, is another one, or if
+	// its digests are shorter than the curve order, which weakens the
+	// signature to the strength of the hash.
+	ExpectedHash crypto.Hash
+
+	// NormalizeText, if set, is applied to the message before it is
+	// verified, for text signed after a normalization such as
+	// NormalizeLineEndings, so that signatures made on one platform verify
+	// on another. It applies to every key type. Never set it for binary
+	// content, whose signatures would then cover other bytes than the ones
+	// used.
+	NormalizeText func(msg []byte) []byte
+}
+
+// NormalizeLineEndings returns msg with CRLF line endings converted to LF,
+// for use as VerifyOptions.NormalizeText. Lone CR characters are kept.
+func NormalizeLineEndings(msg []byte) []byte {
+	return bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+}
+
+// ErrHashMismatch is returned when the hash used to verify a signature is not
+// the one expected with VerifyOptions.ExpectedHash.
+var ErrHashMismatch = errors.New("tuf: hash function does not match the expected one")
+
+// optionsVerifier is implemented by verifiers supporting VerifyOptions.
+type optionsVerifier interface {
+	verifyWithOptions(msg, sig []byte, opts *VerifyOptions) error
+}
+
+// VerifyWithOptions verifies sig over msg like v.Verify, applying opts.
+// Options that do not apply to the key type of v are ignored.
+func VerifyWithOptions(v Verifier, msg, sig []byte, opts *VerifyOptions) error {
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+	if opts.NormalizeText != nil {
+		msg = opts.NormalizeText(msg)
+	}
+	if ov, ok := v.(optionsVerifier); ok {
+		return ov.verifyWithOptions(msg, sig, opts)
+	}
+	return v.Verify(msg, sig)
+}
+
+// SignerOptions holds optional settings for GetSignerWithOptions. The zero
+// value gives the same behaviour as GetSigner.
+type SignerOptions struct {
+	// SkipConsistencyCheck skips checking that the public key stored with a
+	// private key is the one derived from the private key, which costs a
+	// scalar multiplication per key, and that the stored key ID, if any,
+	// is the ID of the key.
+	//
+	// Only set it for keys from a trusted source: with the check skipped, a
+	// key whose public part was tampered with or corrupted loads fine, and
+	// produces signatures that do not verify against the key ID it claims,
+	// or claims the ID of somebody else's key.
+	SkipConsistencyCheck bool
+
+	// Rand is the random source of ECDSA signers. The zero value uses
+	// crypto/rand.Reader.
+	Rand io.Reader
+
+	// ValidateRand wraps the random source with NewValidatingRandSource,
+	// so that signing fails instead of risking nonce reuse when the source
+	// is broken.
+	ValidateRand bool
+}
+
+// optionsSigner is implemented by signers supporting SignerOptions.
+type optionsSigner interface {
+	unmarshalPrivateKeyWithOptions(key *data.PrivateKey, opts *SignerOptions) error
+}
+
+// GetSignerWithOptions returns a Signer for key like GetSigner, applying
+// opts. Options that do not apply to the key type are ignored.
+func GetSignerWithOptions(key *data.PrivateKey, opts *SignerOptions) (Signer, error) {
+	if opts == nil {
+		opts = &SignerOptions{}
+	}
+	st, ok := SignerMap.Load(key.Type)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	s := st.(func() Signer)()
+	o, ok := s.(optionsSigner)
+	if !ok {
+		return GetSigner(key)
+	}
+	if err := o.unmarshalPrivateKeyWithOptions(key, opts); err != nil {
+		return nil, fmt.Errorf("tuf: error unmarshalling key: %w", err)
+	}
+	if !opts.SkipConsistencyCheck {
+		if err := checkStoredKeyID(key, s); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// PublicKeyOptions holds optional settings for ToPublicKey.
+type PublicKeyOptions struct {
+	// KeyIDHashAlgorithms is recorded as the keyid_hash_algorithms of the
+	// key. The field is part of the key object, so it changes the key IDs
+	// computed from it. The zero value uses data.HashAlgorithms, sha256
+	// and sha512, like the signers of this package.
+	KeyIDHashAlgorithms []string
+}
@@ -0,0 +1,312 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type OptionsSuite struct{}
+
+var _ = Suite(&OptionsSuite{})
+
+func (OptionsSuite) TestAutoSignatureFormat(c *C) {
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA_SHA2_P384, data.KeyTypeECDSA_SHA2_P521} {
+		signer, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		msg := []byte("foo")
+		digest, err := signer.digest(msg)
+		c.Assert(err, IsNil)
+
+		r, s, err := ecdsa.Sign(rand.Reader, signer.PrivateKey, digest)
+		c.Assert(err, IsNil)
+		size := curveByteSize(signer.Curve)
+		raw := append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+		der, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+		auto := &VerifyOptions{AutoSignatureFormat: true}
+		c.Assert(VerifyWithOptions(verifier, msg, raw, auto), IsNil)
+		c.Assert(VerifyWithOptions(verifier, msg, der, auto), IsNil)
+		c.Assert(VerifyWithOptions(verifier, []byte("bar"), raw, auto), NotNil)
+
+		// Raw signatures are only accepted when requested.
+		c.Assert(verifier.Verify(msg, raw), NotNil)
+		c.Assert(VerifyWithOptions(verifier, msg, raw, nil), NotNil)
+		c.Assert(VerifyWithOptions(verifier, msg, der, nil), IsNil)
+	}
+}
+
+func (OptionsSuite) TestVerifyWithOptionsIgnoresUnrelatedOptions(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(VerifyWithOptions(verifier, msg, sig, &VerifyOptions{AutoSignatureFormat: true}), IsNil)
+}
+
+// mismatchedPrivateKey returns the private key of a signer generated by gen,
+// with its public part replaced by the one of another key.
+func mismatchedPrivateKey(c *C, gen func() (Signer, error)) *data.PrivateKey {
+	signer, err := gen()
+	c.Assert(err, IsNil)
+	other, err := gen()
+	c.Assert(err, IsNil)
+	pk, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	otherPk, err := other.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+
+	var value, otherValue map[string]json.RawMessage
+	c.Assert(json.Unmarshal(pk.Value, &value), IsNil)
+	c.Assert(json.Unmarshal(otherPk.Value, &otherValue), IsNil)
+	value["public"] = otherValue["public"]
+	pk.Value, err = json.Marshal(value)
+	c.Assert(err, IsNil)
+	return pk
+}
+
+func (OptionsSuite) TestSkipConsistencyCheck(c *C) {
+	for _, gen := range []func() (Signer, error){
+		func() (Signer, error) { return GenerateEd25519Key() },
+		func() (Signer, error) { return GenerateEcdsaKey() },
+	} {
+		pk := mismatchedPrivateKey(c, gen)
+
+		_, err := GetSigner(pk)
+		c.Assert(err, ErrorMatches, "tuf: error unmarshalling key: tuf: .* public and private keys do not match")
+		_, err = GetSignerWithOptions(pk, nil)
+		c.Assert(err, ErrorMatches, "tuf: error unmarshalling key: tuf: .* public and private keys do not match")
+
+		signer, err := GetSignerWithOptions(pk, &SignerOptions{SkipConsistencyCheck: true})
+		c.Assert(err, IsNil)
+		c.Assert(signer, NotNil)
+	}
+}
+
+func (OptionsSuite) TestGetSignerWithOptions(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	pk, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+
+	for _, opts := range []*SignerOptions{nil, {SkipConsistencyCheck: true}} {
+		s, err := GetSignerWithOptions(pk, opts)
+		c.Assert(err, IsNil)
+		c.Assert(s.PublicData(), DeepEquals, signer.PublicData())
+	}
+
+	_, err = GetSignerWithOptions(&data.PrivateKey{Type: "unknown"}, nil)
+	c.Assert(err, Equals, ErrInvalidKey)
+}
+
+func benchmarkImportKeys(b *testing.B, opts *SignerOptions) {
+	pks := make([]*data.PrivateKey, 10000)
+	for i := range pks {
+		signer, err := GenerateEcdsaKey()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if pks[i], err = signer.MarshalPrivateKey(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, pk := range pks {
+			if _, err := GetSignerWithOptions(pk, opts); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkImportKeys(b *testing.B) {
+	benchmarkImportKeys(b, nil)
+}
+
+func BenchmarkImportKeysSkipConsistencyCheck(b *testing.B) {
+	benchmarkImportKeys(b, &SignerOptions{SkipConsistencyCheck: true})
+}
+
+// webCryptoVector is a P-256 signature over SHA-384 in the raw r||s (IEEE
+// P1363) format produced by WebCrypto's SubtleCrypto.sign with
+// {name: "ECDSA", hash: "SHA-384"}.
+var webCryptoVector = struct {
+	public    string
+	message   string
+	signature string
+}{
+	public:    "04e1fe945fbd3343db945366d3b66d253e2bedc5b3f0f6476519582024da08c1c0b0a69d1db5157021441559d5a7b96841b854900bbd79e7edacd740dc6075ca06",
+	message:   "hello from the browser",
+	signature: "dd7dbf53c2d998b02361f627fa9a533963fabd4724fdd69ab44da80b328eb839393b1fcfd6b8fb4f64f1302ca4e02504070b5bd98a6ae143837b35e933abd06c",
+}
+
+func (OptionsSuite) TestHashOverrideWebCrypto(c *C) {
+	public, err := hex.DecodeString(webCryptoVector.public)
+	c.Assert(err, IsNil)
+	sig, err := hex.DecodeString(webCryptoVector.signature)
+	c.Assert(err, IsNil)
+	msg := []byte(webCryptoVector.message)
+
+	value, err := json.Marshal(ecdsaVerifier{PublicKey: public})
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(&data.PublicKey{
+		Type:   data.KeyTypeECDSA_SHA2_P256,
+		Scheme: data.KeySchemeECDSA_SHA2_P256,
+		Value:  value,
+	})
+	c.Assert(err, IsNil)
+
+	opts := &VerifyOptions{AutoSignatureFormat: true, Hash: crypto.SHA384}
+	c.Assert(VerifyWithOptions(verifier, msg, sig, opts), IsNil)
+	c.Assert(VerifyWithOptions(verifier, []byte("hello from the server"), sig, opts), NotNil)
+
+	// The hash of the key scheme, SHA-256, does not match.
+	c.Assert(VerifyWithOptions(verifier, msg, sig, &VerifyOptions{AutoSignatureFormat: true}), NotNil)
+
+	// DER encoded signatures work with the hash override too.
+	der, err := EcdsaRawToDER(sig, 32)
+	c.Assert(err, IsNil)
+	c.Assert(VerifyWithOptions(verifier, msg, der, &VerifyOptions{Hash: crypto.SHA384}), IsNil)
+
+	err = VerifyWithOptions(verifier, msg, sig, &VerifyOptions{AutoSignatureFormat: true, Hash: crypto.Hash(999)})
+	c.Assert(errors.Is(err, ErrInvalidArgument), Equals, true)
+}
+
+func (OptionsSuite) TestExpectedHash(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+
+	// A P-256 signature made with SHA-512, whose digest ECDSA truncates to
+	// 32 bytes. Nothing in the signature tells which hash was used.
+	msg := []byte("foo")
+	digest := crypto.SHA512.New()
+	digest.Write(msg)
+	sig, err := ecdsa.SignASN1(rand.Reader, signer.PrivateKey, digest.Sum(nil))
+	c.Assert(err, IsNil)
+
+	// With the hash of the key scheme it merely fails to verify.
+	c.Assert(verifier.Verify(msg, sig), NotNil)
+	c.Assert(VerifyWithOptions(verifier, msg, sig, &VerifyOptions{Hash: crypto.SHA512}), IsNil)
+
+	// With the expected hash recorded, the mismatch is reported as such.
+	err = VerifyWithOptions(verifier, msg, sig, &VerifyOptions{ExpectedHash: crypto.SHA512})
+	c.Assert(errors.Is(err, ErrHashMismatch), Equals, true)
+	opts := &VerifyOptions{Hash: crypto.SHA512, ExpectedHash: crypto.SHA512}
+	c.Assert(VerifyWithOptions(verifier, msg, sig, opts), IsNil)
+	c.Assert(VerifyWithOptions(verifier, []byte("bar"), sig, opts), NotNil)
+
+	// SHA-256 signatures of the key scheme still verify.
+	sig, err = signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(VerifyWithOptions(verifier, msg, sig, &VerifyOptions{ExpectedHash: crypto.SHA256}), IsNil)
+
+	// A hash shorter than the curve order is rejected.
+	p384, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	c.Assert(err, IsNil)
+	verifier, err = GetVerifier(p384.PublicData())
+	c.Assert(err, IsNil)
+	digest = crypto.SHA256.New()
+	digest.Write(msg)
+	sig, err = ecdsa.SignASN1(rand.Reader, p384.PrivateKey, digest.Sum(nil))
+	c.Assert(err, IsNil)
+	c.Assert(VerifyWithOptions(verifier, msg, sig, &VerifyOptions{Hash: crypto.SHA256}), IsNil)
+	err = VerifyWithOptions(verifier, msg, sig, &VerifyOptions{Hash: crypto.SHA256, ExpectedHash: crypto.SHA256})
+	c.Assert(errors.Is(err, ErrHashMismatch), Equals, true)
+}
+
+// javaStyleSignature returns the r||s signature of msg without left-padding
+// either value to the curve size, as some older Java signers do. It signs
+// until the value selected by short, r or s, has a leading zero byte.
+func javaStyleSignature(c *C, signer *ecdsaSigner, msg []byte, short func(r, s *big.Int) bool) []byte {
+	digest := sha256.Sum256(msg)
+	for i := 0; i < 10000; i++ {
+		r, s, err := ecdsa.Sign(rand.Reader, signer.PrivateKey, digest[:])
+		c.Assert(err, IsNil)
+		if short(r, s) {
+			return append(r.Bytes(), s.Bytes()...)
+		}
+	}
+	c.Fatal("no signature with a short value")
+	return nil
+}
+
+func (OptionsSuite) TestLenientSignatureLength(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	lenient := &VerifyOptions{AutoSignatureFormat: true, LenientSignatureLength: true}
+
+	shortR := func(r, s *big.Int) bool { return len(r.Bytes()) < 32 && len(s.Bytes()) == 32 }
+	shortS := func(r, s *big.Int) bool { return len(r.Bytes()) == 32 && len(s.Bytes()) < 32 }
+	for _, short := range []func(r, s *big.Int) bool{shortR, shortS} {
+		sig := javaStyleSignature(c, signer, msg, short)
+		c.Assert(len(sig) < 64, Equals, true)
+
+		// Rejected by default.
+		c.Assert(verifier.Verify(msg, sig), NotNil)
+		c.Assert(VerifyWithOptions(verifier, msg, sig, &VerifyOptions{AutoSignatureFormat: true}), NotNil)
+
+		c.Assert(VerifyWithOptions(verifier, msg, sig, lenient), IsNil)
+		c.Assert(VerifyWithOptions(verifier, []byte("bar"), sig, lenient), NotNil)
+	}
+
+	// Padded raw and DER signatures still verify.
+	der, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(VerifyWithOptions(verifier, msg, der, lenient), IsNil)
+	raw, err := EcdsaDERToRaw(der, 32)
+	c.Assert(err, IsNil)
+	c.Assert(VerifyWithOptions(verifier, msg, raw, lenient), IsNil)
+
+	// Truncated signatures are rejected.
+	c.Assert(VerifyWithOptions(verifier, msg, raw[:40], lenient), NotNil)
+	c.Assert(VerifyWithOptions(verifier, msg, raw[:1], lenient), NotNil)
+}
+
+func (OptionsSuite) TestNormalizeText(c *C) {
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ec, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+
+	lf := []byte("name: foo\nversion: 1\n")
+	crlf := []byte("name: foo\r\nversion: 1\r\n")
+	opts := &VerifyOptions{NormalizeText: NormalizeLineEndings}
+	for _, s := range []Signer{ed, ec, rsa} {
+		comment := Commentf("%s", s.PublicData().Type)
+		sig, err := s.SignMessage(lf)
+		c.Assert(err, IsNil)
+		verifier, err := GetVerifier(s.PublicData())
+		c.Assert(err, IsNil)
+
+		// The CRLF content only verifies with the option.
+		c.Assert(VerifyWithOptions(verifier, crlf, sig, nil), NotNil, comment)
+		c.Assert(VerifyWithOptions(verifier, crlf, sig, opts), IsNil, comment)
+		c.Assert(VerifyWithOptions(verifier, lf, sig, opts), IsNil, comment)
+		c.Assert(VerifyWithOptions(verifier, []byte("name: bar\r\nversion: 1\r\n"), sig, opts), NotNil, comment)
+	}
+
+	c.Assert(string(NormalizeLineEndings([]byte("a\r\nb\rc\n\r\n"))), Equals, "a\nb\rc\n\n")
+}
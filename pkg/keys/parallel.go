@@ -0,0 +1,45 @@
+package keys
+
+import (
+	"sync"
+)
+
+// A VerifyItem is a signature to check with VerifyParallel.
+type VerifyItem struct {
+	Verifier  Verifier
+	Message   []byte
+	Signature []byte
+}
+
+// VerifyParallel verifies items using at most workers goroutines. The
+// returned slice holds the verification error of each item, nil for valid
+// signatures, at the same index as the item, regardless of the order in which
+// verifications complete.
+func VerifyParallel(items []VerifyItem, workers int) []error {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	errs := make([]error, len(items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				item := items[i]
+				errs[i] = item.Verifier.Verify(item.Message, item.Signature)
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,89 @@
+package keys
+
+import (
+	"fmt"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+type ParallelSuite struct{}
+
+var _ = Suite(&ParallelSuite{})
+
+// parallelItems returns n items, where every third one has an invalid
+// signature.
+func parallelItems(n int) ([]VerifyItem, error) {
+	signers := make([]Signer, 0, 2)
+	ed25519Key, err := GenerateEd25519Key()
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, err := GenerateEcdsaKey()
+	if err != nil {
+		return nil, err
+	}
+	signers = append(signers, ed25519Key, ecdsaKey)
+
+	items := make([]VerifyItem, n)
+	for i := range items {
+		signer := signers[i%len(signers)]
+		verifier, err := GetVerifier(signer.PublicData())
+		if err != nil {
+			return nil, err
+		}
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := signer.SignMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		if i%3 == 0 {
+			msg = []byte("tampered")
+		}
+		items[i] = VerifyItem{Verifier: verifier, Message: msg, Signature: sig}
+	}
+	return items, nil
+}
+
+func (ParallelSuite) TestVerifyParallel(c *C) {
+	items, err := parallelItems(1000)
+	c.Assert(err, IsNil)
+
+	for _, workers := range []int{0, 1, 8, 2000} {
+		errs := VerifyParallel(items, workers)
+		c.Assert(errs, HasLen, len(items))
+		for i, err := range errs {
+			if i%3 == 0 {
+				c.Assert(err, NotNil, Commentf("item %d", i))
+			} else {
+				c.Assert(err, IsNil, Commentf("item %d", i))
+			}
+		}
+	}
+
+	c.Assert(VerifyParallel(nil, 4), HasLen, 0)
+}
+
+func BenchmarkVerifySerial(b *testing.B) {
+	items, err := parallelItems(100)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, item := range items {
+			_ = item.Verifier.Verify(item.Message, item.Signature)
+		}
+	}
+}
+
+func BenchmarkVerifyParallel(b *testing.B) {
+	items, err := parallelItems(100)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		VerifyParallel(items, 8)
+	}
+}
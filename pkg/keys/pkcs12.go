@@ -0,0 +1,28 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// FromPKCS12 extracts the key pair of a PKCS#12 (.p12) bundle protected by
+// password. The certificates of the bundle are ignored. Only ed25519 keys,
+// ECDSA keys on a registered curve and RSA keys, which sign with
+// RSASSA-PSS, are supported.
+func FromPKCS12(p12 []byte, password string) (*data.PrivateKey, *data.PublicKey, error) {
+	priv, _, _, err := pkcs12.DecodeChain(p12, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tuf: error decoding pkcs12 bundle: %w", err)
+	}
+	signer, err := newSignerFromPrivateKey(priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tuf: error importing pkcs12 key of type %T: %w", priv, err)
+	}
+	privKey, err := signer.MarshalPrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	return privKey, signer.PublicData(), nil
+}
@@ -0,0 +1,91 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+type PKCS12Suite struct{}
+
+var _ = Suite(&PKCS12Suite{})
+
+// generatePKCS12 bundles priv with a self-signed certificate.
+func generatePKCS12(c *C, priv crypto.Signer, password string) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "go-tuf test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	c.Assert(err, IsNil)
+	cert, err := x509.ParseCertificate(der)
+	c.Assert(err, IsNil)
+	p12, err := pkcs12.Encode(rand.Reader, priv, cert, nil, password)
+	c.Assert(err, IsNil)
+	return p12
+}
+
+func (PKCS12Suite) TestFromPKCS12(c *C) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	p12 := generatePKCS12(c, priv, "password")
+
+	privKey, pubKey, err := FromPKCS12(p12, "password")
+	c.Assert(err, IsNil)
+	c.Assert(privKey.Type, Equals, data.KeyTypeECDSA_SHA2_P256)
+	c.Assert(pubKey.Type, Equals, data.KeyTypeECDSA_SHA2_P256)
+
+	signer, err := GetSigner(privKey)
+	c.Assert(err, IsNil)
+	c.Assert(signer.PublicData().IDs(), DeepEquals, pubKey.IDs())
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(VerifyEcdsaPoint(data.KeyTypeECDSA_SHA2_P256, elliptic.Marshal(priv.Curve, priv.X, priv.Y), msg, sig), IsNil)
+}
+
+func (PKCS12Suite) TestFromPKCS12WrongPassword(c *C) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	p12 := generatePKCS12(c, priv, "password")
+	_, _, err = FromPKCS12(p12, "wrong")
+	c.Assert(err, ErrorMatches, "tuf: error decoding pkcs12 bundle: .*")
+}
+
+func (PKCS12Suite) TestFromPKCS12Rsa(c *C) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, IsNil)
+	p12 := generatePKCS12(c, priv, "password")
+
+	privKey, pubKey, err := FromPKCS12(p12, "password")
+	c.Assert(err, IsNil)
+	c.Assert(privKey.Type, Equals, data.KeyTypeRSASSA_PSS_SHA256)
+	c.Assert(pubKey.Scheme, Equals, data.KeySchemeRSASSA_PSS_SHA256)
+
+	signer, err := GetSigner(privKey)
+	c.Assert(err, IsNil)
+	c.Assert(signer.PublicData().IDs(), DeepEquals, pubKey.IDs())
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	digest := sha256.Sum256(msg)
+	c.Assert(rsa.VerifyPSS(&priv.PublicKey, crypto.SHA256, digest[:], sig, nil), IsNil)
+}
+
+func (PKCS12Suite) TestFromPKCS12UnsupportedKey(c *C) {
+	_, err := newSignerFromPrivateKey(struct{}{})
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+}
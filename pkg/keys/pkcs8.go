@@ -0,0 +1,221 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/internal/zeroize"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrBadPassphrase is returned by FromEncryptedPEM when the key cannot be
+// decrypted with the passphrase.
+var ErrBadPassphrase = errors.New("tuf: incorrect passphrase")
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// Upper bounds on the cost of the key derivation, so that a crafted key
+// cannot make FromEncryptedPEM arbitrarily expensive. scrypt needs 128·N·r
+// bytes of memory, and repeats its work r·p times.
+const (
+	maxPBKDF2Iterations = 10000000
+	maxScryptMemory     = 256 << 20
+	maxScryptRP         = 32
+)
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     algorithmIdentifier
+	EncryptedData []byte
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc algorithmIdentifier
+	EncryptionScheme  algorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                 `asn1:"optional"`
+	PRF            algorithmIdentifier `asn1:"optional"`
+}
+
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// FromEncryptedPEM decrypts a PEM encoded, passphrase protected PKCS #8 key
+// ("ENCRYPTED PRIVATE KEY") and returns it as a TUF private key. Keys
+// encrypted with PBES2, using PBKDF2 or scrypt and AES-CBC, are supported,
+// which covers the output of "openssl pkcs8 -topk8". ErrBadPassphrase is
+// returned if the passphrase is wrong.
+func FromEncryptedPEM(pemBytes, passphrase []byte) (*data.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != "ENCRYPTED PRIVATE KEY" {
+		return nil, errors.New("tuf: no encrypted private key PEM block found")
+	}
+	der, err := decryptPKCS8(block.Bytes, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroize.Bytes(der)
+
+	priv, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		// A wrong passphrase yields valid padding once in a while.
+		return nil, ErrBadPassphrase
+	}
+	signer, err := newSignerFromPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return signer.MarshalPrivateKey()
+}
+
+func decryptPKCS8(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) != 0 {
+		return nil, errors.New("tuf: invalid encrypted private key")
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("tuf: unsupported private key encryption %v", info.Algorithm.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.New("tuf: invalid PBES2 parameters")
+	}
+
+	var keySize int
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keySize = 16
+	case scheme.Equal(oidAES192CBC):
+		keySize = 24
+	case scheme.Equal(oidAES256CBC):
+		keySize = 32
+	default:
+		return nil, fmt.Errorf("tuf: unsupported private key cipher %v", scheme)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("tuf: invalid private key cipher parameters")
+	}
+
+	key, err := pbes2Key(params.KeyDerivationFunc, passphrase, keySize)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroize.Bytes(key)
+
+	ciphertext := info.EncryptedData
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("tuf: invalid encrypted private key length")
+	}
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(b, iv).CryptBlocks(plaintext, ciphertext)
+
+	// Check and strip the PKCS #7 padding, which fails with a wrong
+	// passphrase in most cases.
+	n := int(plaintext[len(plaintext)-1])
+	if n == 0 || n > aes.BlockSize || !bytes.Equal(plaintext[len(plaintext)-n:], bytes.Repeat([]byte{byte(n)}, n)) {
+		zeroize.Bytes(plaintext)
+		return nil, ErrBadPassphrase
+	}
+	return plaintext[:len(plaintext)-n], nil
+}
+
+// checkScryptParams checks that the scrypt parameters n, r and p are valid,
+// as scrypt.Key panics on some invalid ones, and that their cost is bounded.
+func checkScryptParams(n, r, p int) error {
+	if n <= 1 || n&(n-1) != 0 || r < 1 || p < 1 {
+		return errors.New("tuf: invalid scrypt parameters")
+	}
+	if n > maxScryptMemory/128/r || p > maxScryptRP/r {
+		return errors.New("tuf: scrypt parameters too large")
+	}
+	return nil
+}
+
+// pbes2Key derives the encryption key from passphrase with the PBES2 key
+// derivation function kdf.
+func pbes2Key(kdf algorithmIdentifier, passphrase []byte, keySize int) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.New("tuf: invalid PBKDF2 parameters")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, errors.New("tuf: invalid PBKDF2 key length")
+		}
+		if params.IterationCount < 1 || params.IterationCount > maxPBKDF2Iterations {
+			return nil, errors.New("tuf: invalid PBKDF2 iteration count")
+		}
+		var h func() hash.Hash
+		switch prf := params.PRF.Algorithm; {
+		case len(prf) == 0 || prf.Equal(oidHMACWithSHA1):
+			h = sha1.New
+		case prf.Equal(oidHMACWithSHA256):
+			h = sha256.New
+		case prf.Equal(oidHMACWithSHA384):
+			h = sha512.New384
+		case prf.Equal(oidHMACWithSHA512):
+			h = sha512.New
+		default:
+			return nil, fmt.Errorf("tuf: unsupported PBKDF2 function %v", prf)
+		}
+		return pbkdf2.Key(passphrase, params.Salt, params.IterationCount, keySize, h), nil
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.New("tuf: invalid scrypt parameters")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, errors.New("tuf: invalid scrypt key length")
+		}
+		if err := checkScryptParams(params.CostParameter, params.BlockSize, params.ParallelizationParameter); err != nil {
+			return nil, err
+		}
+		return scrypt.Key(passphrase, params.Salt, params.CostParameter, params.BlockSize, params.ParallelizationParameter, keySize)
+	default:
+		return nil, fmt.Errorf("tuf: unsupported key derivation function %v", kdf.Algorithm)
+	}
+}
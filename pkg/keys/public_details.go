@@ -0,0 +1,64 @@
+package keys
+
+import (
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// PublicKeyEncoding is the encoding of the public value of a key object.
+type PublicKeyEncoding string
+
+const (
+	// PublicKeyEncodingRaw is the encoding of ed25519 public keys, the 32
+	// bytes of the key.
+	PublicKeyEncodingRaw PublicKeyEncoding = "raw"
+	// PublicKeyEncodingUncompressed is the uncompressed SEC1 encoding of
+	// ECDSA points, the one written by the signers of this package.
+	PublicKeyEncodingUncompressed PublicKeyEncoding = "uncompressed"
+	// PublicKeyEncodingPEM is the encoding of RSA public keys, a PKIX or
+	// PKCS#1 PEM block, and of ECDSA keys from python's securesystemslib, a
+	// PKIX PEM block.
+	PublicKeyEncodingPEM PublicKeyEncoding = "pem"
+)
+
+// VerifierDetails is a Verifier along with the public value it was parsed
+// from, as returned by GetVerifierDetailed.
+type VerifierDetails struct {
+	Verifier Verifier
+
+	// RawPublic holds the bytes of the "public" field of the key value as
+	// they were parsed: the decoded hex bytes for ed25519 and ECDSA keys, and
+	// the PEM text for RSA keys and PEM encoded ECDSA keys. It is nil for
+	// custom key types.
+	RawPublic []byte
+	// Encoding is the encoding of RawPublic, empty for custom key types.
+	Encoding PublicKeyEncoding
+}
+
+// GetVerifierDetailed returns the Verifier for key like GetVerifier, along
+// with the original bytes of its public value and their encoding. Key IDs
+// are computed over these bytes, so comparing them helps diagnose key ID
+// mismatches caused by re-encoding a key, for example from PKIX to PKCS#1
+// RSA PEM blocks.
+func GetVerifierDetailed(key *data.PublicKey) (*VerifierDetails, error) {
+	verifier, err := GetVerifier(key)
+	if err != nil {
+		return nil, err
+	}
+	details := &VerifierDetails{Verifier: verifier}
+	switch k := verifier.(type) {
+	case *ed25519Verifier:
+		details.RawPublic = append([]byte(nil), k.PublicKey...)
+		details.Encoding = PublicKeyEncodingRaw
+	case *ecdsaVerifier:
+		details.RawPublic = append([]byte(nil), k.PublicKey...)
+		details.Encoding = PublicKeyEncodingUncompressed
+		if k.pem != "" {
+			details.RawPublic = []byte(k.pem)
+			details.Encoding = PublicKeyEncodingPEM
+		}
+	case *rsaVerifier:
+		details.RawPublic = []byte(k.PublicKey)
+		details.Encoding = PublicKeyEncodingPEM
+	}
+	return details, nil
+}
@@ -0,0 +1,64 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type PublicDetailsSuite struct{}
+
+var _ = Suite(&PublicDetailsSuite{})
+
+func (PublicDetailsSuite) TestGetVerifierDetailed(c *C) {
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ec, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+
+	compressed := CompressEcdsaPoint(&ec.PublicKey)
+	compressedKey := &data.PublicKey{
+		Type:   data.KeyTypeECDSA_SHA2_P256,
+		Scheme: data.KeySchemeECDSA_SHA2_P256,
+		Value:  json.RawMessage(fmt.Sprintf(`{"public":"%x"}`, compressed)),
+	}
+	var rsaValue rsaPublic
+	c.Assert(json.Unmarshal(rsa.PublicData().Value, &rsaValue), IsNil)
+
+	for _, t := range []struct {
+		key      *data.PublicKey
+		raw      []byte
+		encoding PublicKeyEncoding
+	}{
+		{ed.PublicData(), ed.PrivateKey.Public().(ed25519.PublicKey), PublicKeyEncodingRaw},
+		{ec.PublicData(), mustHex(c, mustPublicHex(c, ec.PublicData())), PublicKeyEncodingUncompressed},
+		{rsa.PublicData(), []byte(rsaValue.PublicKey), PublicKeyEncodingPEM},
+	} {
+		details, err := GetVerifierDetailed(t.key)
+		c.Assert(err, IsNil)
+		c.Assert(details.RawPublic, DeepEquals, t.raw, Commentf("%s", t.encoding))
+		c.Assert(details.Encoding, Equals, t.encoding)
+		c.Assert(details.Verifier.MarshalPublicKey().IDs(), DeepEquals, t.key.IDs())
+	}
+
+	// Compressed points would give the key a second ID.
+	_, err = GetVerifierDetailed(compressedKey)
+	c.Assert(errors.Is(err, ErrMalformedKey), Equals, true)
+
+	_, err = GetVerifierDetailed(&data.PublicKey{Type: "unknown"})
+	c.Assert(err, Equals, ErrInvalidKey)
+}
+
+func mustPublicHex(c *C, pk *data.PublicKey) string {
+	var value struct {
+		Public string `json:"public"`
+	}
+	c.Assert(json.Unmarshal(pk.Value, &value), IsNil)
+	return value.Public
+}
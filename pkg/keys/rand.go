@@ -0,0 +1,88 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrBadRandomSource is returned when a random source wrapped by
+// NewValidatingRandSource misbehaves.
+var ErrBadRandomSource = errors.New("tuf: random source misbehaved")
+
+// minValidatedRead is the size from which reads are checked for all-zero and
+// repeated output. Shorter reads, such as the single bytes some standard
+// library functions draw, legitimately hit these cases too often.
+const minValidatedRead = 16
+
+// NewValidatingRandSource wraps the random source r so that its reads fill
+// the whole buffer, reading from r as many times as needed, and fail with
+// ErrBadRandomSource when r returns an error before the buffer is full, or,
+// for reads of 16 bytes or more, only zeros or the same bytes as the
+// previous such read. It guards ECDSA signers against a broken source causing nonce
+// reuse, which reveals the private key.
+//
+// The checks only detect grossly broken sources: passing them does not make
+// a source random.
+func NewValidatingRandSource(r io.Reader) io.Reader {
+	return &validatingReader{r: r}
+}
+
+type validatingReader struct {
+	r io.Reader
+
+	mu   sync.Mutex
+	last []byte
+}
+
+func (v *validatingReader) Read(p []byte) (int, error) {
+	// Sources may return fewer bytes than requested, so read until p is
+	// full. Failed reads report no bytes read, as io.ReadFull ignores the
+	// error of a read returning enough bytes.
+	n, err := io.ReadFull(v.r, p)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrBadRandomSource, err)
+	}
+	if len(p) < minValidatedRead {
+		return n, nil
+	}
+	if bytes.Count(p, []byte{0}) == len(p) {
+		return 0, fmt.Errorf("%w: all-zero output", ErrBadRandomSource)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if bytes.Equal(p, v.last) {
+		return 0, fmt.Errorf("%w: repeated output", ErrBadRandomSource)
+	}
+	v.last = append(v.last[:0], p...)
+	return n, nil
+}
+
+// checkRandSource reads size bytes from r if it is a validating source, so
+// that a misbehaving source fails the operation even when the standard
+// library, as recent Go versions do, ignores the source it is given.
+func checkRandSource(r io.Reader, size int) error {
+	if _, ok := r.(*validatingReader); !ok {
+		return nil
+	}
+	_, err := io.ReadFull(r, make([]byte, size))
+	return err
+}
+
+// randSource returns r, or crypto/rand.Reader if r is nil, wrapped with
+// NewValidatingRandSource if validate is set.
+func randSource(r io.Reader, validate bool) io.Reader {
+	if r == nil {
+		r = rand.Reader
+	}
+	if validate {
+		if _, ok := r.(*validatingReader); !ok {
+			r = NewValidatingRandSource(r)
+		}
+	}
+	return r
+}
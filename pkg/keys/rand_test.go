@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	. "gopkg.in/check.v1"
+)
+
+type RandSuite struct{}
+
+var _ = Suite(&RandSuite{})
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+type constReader byte
+
+func (r constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+// chunkedReader returns at most 5 bytes per read.
+type chunkedReader struct{}
+
+func (chunkedReader) Read(p []byte) (int, error) {
+	if len(p) > 5 {
+		p = p[:5]
+	}
+	return rand.Read(p)
+}
+
+// truncatedReader returns 5 bytes, then io.EOF.
+type truncatedReader struct {
+	n int
+}
+
+func (r *truncatedReader) Read(p []byte) (int, error) {
+	if r.n >= 5 {
+		return 0, io.EOF
+	}
+	if len(p) > 5-r.n {
+		p = p[:5-r.n]
+	}
+	n, err := chunkedReader{}.Read(p)
+	r.n += n
+	return n, err
+}
+
+func (RandSuite) TestValidatingRandSource(c *C) {
+	buf := make([]byte, 32)
+
+	r := NewValidatingRandSource(rand.Reader)
+	for i := 0; i < 3; i++ {
+		_, err := io.ReadFull(r, buf)
+		c.Assert(err, IsNil)
+	}
+
+	_, err := NewValidatingRandSource(zeroReader{}).Read(buf)
+	c.Assert(errors.Is(err, ErrBadRandomSource), Equals, true)
+
+	// Sources returning fewer bytes than requested per read are fine, as
+	// long as they fill the buffer eventually.
+	r = NewValidatingRandSource(chunkedReader{})
+	for i := 0; i < 3; i++ {
+		n, err := r.Read(buf)
+		c.Assert(err, IsNil)
+		c.Assert(n, Equals, len(buf))
+	}
+
+	n, err := NewValidatingRandSource(&truncatedReader{}).Read(buf)
+	c.Assert(errors.Is(err, ErrBadRandomSource), Equals, true)
+	c.Assert(n, Equals, 0)
+
+	r = NewValidatingRandSource(constReader(0x42))
+	_, err = r.Read(buf)
+	c.Assert(err, IsNil)
+	_, err = r.Read(buf)
+	c.Assert(errors.Is(err, ErrBadRandomSource), Equals, true)
+
+	// Short reads may be zero or repeated.
+	r = NewValidatingRandSource(zeroReader{})
+	for i := 0; i < 3; i++ {
+		_, err = r.Read(buf[:1])
+		c.Assert(err, IsNil)
+	}
+}
+
+func (RandSuite) TestSignWithBrokenRandSource(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	privKey, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+
+	for _, r := range []io.Reader{zeroReader{}, &truncatedReader{}} {
+		s, err := GetSignerWithOptions(privKey, &SignerOptions{Rand: r, ValidateRand: true})
+		c.Assert(err, IsNil)
+		_, err = s.SignMessage(msg)
+		c.Assert(errors.Is(err, ErrBadRandomSource), Equals, true)
+	}
+
+	// A source repeating itself fails by the second signature at the
+	// latest, depending on how much randomness crypto/ecdsa draws.
+	s, err := GetSignerWithOptions(privKey, &SignerOptions{Rand: constReader(0x42), ValidateRand: true})
+	c.Assert(err, IsNil)
+	_, err1 := s.SignMessage(msg)
+	_, err2 := s.SignMessage(msg)
+	c.Assert(errors.Is(err1, ErrBadRandomSource) || errors.Is(err2, ErrBadRandomSource), Equals, true)
+}
+
+func (RandSuite) TestSignWithValidatedRandSource(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	privKey, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+
+	for _, opts := range []*SignerOptions{
+		{ValidateRand: true},
+		{Rand: NewValidatingRandSource(rand.Reader)},
+		{Rand: chunkedReader{}, ValidateRand: true},
+	} {
+		s, err := GetSignerWithOptions(privKey, opts)
+		c.Assert(err, IsNil)
+		for i := 0; i < 3; i++ {
+			sig, err := s.SignMessage([]byte("foo"))
+			c.Assert(err, IsNil)
+			c.Assert(verifier.Verify([]byte("foo"), sig), IsNil)
+		}
+	}
+}
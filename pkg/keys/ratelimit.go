@@ -0,0 +1,42 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+)
+
+// A Limiter throttles signing operations of a Signer wrapped with
+// RateLimitSigner. *rate.Limiter of golang.org/x/time/rate implements it.
+type Limiter interface {
+	// Wait blocks until an operation is allowed, or returns an error if it
+	// never will be.
+	Wait(ctx context.Context) error
+}
+
+// RateLimitSigner returns a Signer that waits for limiter before every call
+// to SignMessage, for example to stay below the request rate allowed by a
+// remote signing service. If limiter fails, SignMessage returns its error
+// without signing.
+func RateLimitSigner(s Signer, limiter Limiter) Signer {
+	return &rateLimitedSigner{Signer: s, limiter: limiter}
+}
+
+type rateLimitedSigner struct {
+	Signer
+	limiter Limiter
+}
+
+func (s *rateLimitedSigner) SignMessage(message []byte) ([]byte, error) {
+	if err := s.limiter.Wait(context.Background()); err != nil {
+		return nil, fmt.Errorf("tuf: signing rate limit: %w", err)
+	}
+	return s.Signer.SignMessage(message)
+}
+
+func (s *rateLimitedSigner) digest(message []byte) ([]byte, error) {
+	return signerDigest(s.Signer, message)
+}
+
+func (s *rateLimitedSigner) deterministic() bool {
+	return Deterministic(s.Signer)
+}
@@ -0,0 +1,54 @@
+package keys
+
+import (
+	"context"
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type RateLimitSuite struct{}
+
+var _ = Suite(&RateLimitSuite{})
+
+// countingLimiter is a Limiter counting its calls, and failing once it
+// allowed max operations.
+type countingLimiter struct {
+	calls int
+	max   int
+}
+
+var errRateLimited = errors.New("rate limited")
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.calls++
+	if l.calls > l.max {
+		return errRateLimited
+	}
+	return nil
+}
+
+func (RateLimitSuite) TestRateLimitSigner(c *C) {
+	key, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	limiter := &countingLimiter{max: 3}
+	signer := RateLimitSigner(key, limiter)
+	c.Assert(signer.PublicData().IDs(), DeepEquals, key.PublicData().IDs())
+	c.Assert(Deterministic(signer), Equals, true)
+
+	msg := []byte("foo")
+	for i := 1; i <= 3; i++ {
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+		c.Assert(verifyWith(c, key, msg, sig), IsNil)
+		c.Assert(limiter.calls, Equals, i)
+	}
+
+	// The limiter error is returned, without signing, which would fail
+	// with another error.
+	inner := &slowSigner{Signer: key, err: errors.New("signed")}
+	limited := RateLimitSigner(inner, limiter)
+	_, err = limited.SignMessage(msg)
+	c.Assert(errors.Is(err, errRateLimited), Equals, true)
+	c.Assert(limiter.calls, Equals, 4)
+}
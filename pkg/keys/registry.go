@@ -0,0 +1,60 @@
+package keys
+
+import (
+	"sync"
+)
+
+// registries lists the global maps that make up the key type registry.
+var registries = []*sync.Map{&SignerMap, &VerifierMap, &ecdsaKeyTypes, &keySchemes, &algorithmAliases}
+
+// registryMu is held by the registration functions while they update the
+// registry, and by SnapshotRegistry while it copies it, so that a snapshot
+// never captures part of a registration.
+var registryMu sync.RWMutex
+
+// SnapshotRegistry captures the current registrations of signers, verifiers,
+// key schemes, algorithm aliases, ECDSA key types and key converters, and
+// returns a function restoring the registry to that snapshot. It is meant
+// for tests that register custom key types:
+//
+//	restore := keys.SnapshotRegistry()
+//	defer restore()
+//
+// The snapshot and the restoration are atomic with respect to the
+// registration functions of this package, such as RegisterEcdsaKeyType and
+// RegisterKeyConverter. They are not with respect to direct stores to
+// SignerMap and VerifierMap, which no lock guards.
+func SnapshotRegistry() func() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	snapshots := make([]map[interface{}]interface{}, len(registries))
+	for i, m := range registries {
+		snapshot := make(map[interface{}]interface{})
+		m.Range(func(k, v interface{}) bool {
+			snapshot[k] = v
+			return true
+		})
+		snapshots[i] = snapshot
+	}
+	converters := keyConverters
+
+	return func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+
+		for i, m := range registries {
+			snapshot := snapshots[i]
+			m.Range(func(k, _ interface{}) bool {
+				if _, ok := snapshot[k]; !ok {
+					m.Delete(k)
+				}
+				return true
+			})
+			for k, v := range snapshot {
+				m.Store(k, v)
+			}
+		}
+		keyConverters = converters
+	}
+}
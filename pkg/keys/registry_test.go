@@ -0,0 +1,78 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type RegistrySuite struct{}
+
+var _ = Suite(&RegistrySuite{})
+
+func (RegistrySuite) TestSnapshotRegistry(c *C) {
+	const tempKeyType = "ecdsa-sha2-nistp256-temp"
+
+	restore := SnapshotRegistry()
+	RegisterEcdsaKeyType(tempKeyType, tempKeyType, elliptic.P256(), crypto.SHA256)
+	// Overwrite an existing registration too.
+	VerifierMap.Store(data.KeyTypeEd25519, NewEcdsaVerifier)
+	c.Assert(RequireAlgorithms(tempKeyType), IsNil)
+	_, err := GenerateEcdsaKeyWithType(tempKeyType)
+	c.Assert(err, IsNil)
+
+	restore()
+	c.Assert(RequireAlgorithms(tempKeyType), NotNil)
+	_, ok := SignerMap.Load(tempKeyType)
+	c.Assert(ok, Equals, false)
+	_, err = GenerateEcdsaKeyWithType(tempKeyType)
+	c.Assert(err, NotNil)
+
+	// The overwritten registration is back.
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+	_, ok = verifier.(*ed25519Verifier)
+	c.Assert(ok, Equals, true)
+}
+
+func (RegistrySuite) TestSnapshotRegistryConcurrentRegistration(c *C) {
+	const n = 50
+	keyType := func(i int) string { return fmt.Sprintf("ecdsa-sha2-nistp256-temp-%d", i) }
+
+	restore := SnapshotRegistry()
+	defer restore()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			RegisterEcdsaKeyType(keyType(i), keyType(i), elliptic.P256(), crypto.SHA256)
+		}
+	}()
+	var snapshots []func()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		snapshots = append(snapshots, SnapshotRegistry())
+	}
+
+	// Each snapshot holds all the registrations of a key type, or none.
+	for _, snapshot := range snapshots {
+		snapshot()
+		for i := 0; i < n; i++ {
+			_, ecdsa := ecdsaKeyTypes.Load(keyType(i))
+			_, verifier := VerifierMap.Load(keyType(i))
+			_, signer := SignerMap.Load(keyType(i))
+			_, schemes := keySchemes.Load(keyType(i))
+			c.Assert([]bool{verifier, signer, schemes}, DeepEquals, []bool{ecdsa, ecdsa, ecdsa})
+		}
+	}
+}
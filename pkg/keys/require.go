@@ -0,0 +1,41 @@
+package keys
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrMissingAlgorithms is returned by RequireAlgorithms when some key types
+// have no registered verifier.
+type ErrMissingAlgorithms struct {
+	Names []string
+}
+
+func (e ErrMissingAlgorithms) Error() string {
+	return fmt.Sprintf("tuf: missing algorithms: %s", strings.Join(e.Names, ", "))
+}
+
+// RequireAlgorithms checks that a verifier is registered for each of the
+// given key types. It is meant to be called at startup, so that a binary
+// built without support for a key type it depends on fails early.
+func RequireAlgorithms(names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if !hasVerifier(name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return ErrMissingAlgorithms{missing}
+	}
+	return nil
+}
+
+func hasVerifier(name string) bool {
+	st, ok := VerifierMap.Load(name)
+	if !ok {
+		return false
+	}
+	newVerifier, ok := st.(func() Verifier)
+	return ok && newVerifier != nil && newVerifier() != nil
+}
@@ -0,0 +1,19 @@
+package keys
+
+import (
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type RequireSuite struct{}
+
+var _ = Suite(&RequireSuite{})
+
+func (RequireSuite) TestRequireAlgorithms(c *C) {
+	c.Assert(RequireAlgorithms(), IsNil)
+	c.Assert(RequireAlgorithms(data.KeyTypeEd25519, data.KeyTypeECDSA_SHA2_P256, data.KeyTypeRSASSA_PSS_SHA256), IsNil)
+
+	err := RequireAlgorithms(data.KeyTypeEd25519, "bogus", "other-bogus")
+	c.Assert(err, DeepEquals, ErrMissingAlgorithms{[]string{"bogus", "other-bogus"}})
+	c.Assert(err, ErrorMatches, "tuf: missing algorithms: bogus, other-bogus")
+}
@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
+	"io"
 
 	"github.com/theupdateframework/go-tuf/data"
 )
@@ -16,6 +18,7 @@ import (
 func init() {
 	VerifierMap.Store(data.KeyTypeRSASSA_PSS_SHA256, NewRsaVerifier)
 	SignerMap.Store(data.KeyTypeRSASSA_PSS_SHA256, NewRsaSigner)
+	RegisterKeySchemes(data.KeyTypeRSASSA_PSS_SHA256, data.KeySchemeRSASSA_PSS_SHA256, data.KeySchemeRSA_PKCS1v15_SHA256)
 }
 
 func NewRsaVerifier() Verifier {
@@ -46,7 +49,44 @@ func (p *rsaVerifier) Public() string {
 func (p *rsaVerifier) Verify(msg, sigBytes []byte) error {
 	hash := sha256.Sum256(msg)
 
-	return rsa.VerifyPSS(p.rsaKey, crypto.SHA256, hash[:], sigBytes, &rsa.PSSOptions{})
+	return p.verifyDigest(hash[:], sigBytes)
+}
+
+func (p *rsaVerifier) VerifyReader(r io.Reader, sigBytes []byte) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+
+	return p.verifyDigest(h.Sum(nil), sigBytes)
+}
+
+// verifyDigest verifies a signature of a SHA-256 digest. RSASSA-PSS is used
+// unless the key scheme selects PKCS #1 v1.5.
+func (p *rsaVerifier) verifyDigest(digest, sigBytes []byte) error {
+	if p.key != nil && p.key.Scheme == data.KeySchemeRSA_PKCS1v15_SHA256 {
+		return rsa.VerifyPKCS1v15(p.rsaKey, crypto.SHA256, digest, sigBytes)
+	}
+	return rsa.VerifyPSS(p.rsaKey, crypto.SHA256, digest, sigBytes, &rsa.PSSOptions{})
+}
+
+func (p *rsaVerifier) checkSignatureFormat(sigBytes []byte) error {
+	if len(sigBytes) != p.rsaKey.Size() {
+		return errors.New("tuf: unexpected signature length for rsa key")
+	}
+	return nil
+}
+
+func (p *rsaVerifier) normalizedValue() (json.RawMessage, error) {
+	pub, err := x509.MarshalPKIXPublicKey(p.rsaKey)
+	if err != nil {
+		return nil, err
+	}
+	pubBytes := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: pub,
+	})
+	return json.Marshal(rsaPublic{PublicKey: string(pubBytes)})
 }
 
 func (p *rsaVerifier) MarshalPublicKey() *data.PublicKey {
@@ -57,6 +97,9 @@ func (p *rsaVerifier) UnmarshalPublicKey(key *data.PublicKey) error {
 	if err := json.Unmarshal(key.Value, p); err != nil {
 		return err
 	}
+	if p.PublicKey == "" {
+		return ErrMissingPublicKey
+	}
 	var err error
 	p.rsaKey, err = parseKey(p.PublicKey)
 	if err != nil {
@@ -89,6 +132,17 @@ func parseKey(data string) (*rsa.PublicKey, error) {
 
 type rsaSigner struct {
 	*rsa.PrivateKey
+
+	// scheme is the signature scheme of the key, RSASSA-PSS if empty.
+	scheme string
+}
+
+// keyScheme returns the signature scheme of the key.
+func (s *rsaSigner) keyScheme() string {
+	if s.scheme == "" {
+		return data.KeySchemeRSASSA_PSS_SHA256
+	}
+	return s.scheme
 }
 
 type rsaPublic struct {
@@ -106,7 +160,7 @@ func (s *rsaSigner) PublicData() *data.PublicKey {
 	keyValBytes, _ := json.Marshal(rsaPublic{PublicKey: string(pubBytes)})
 	return &data.PublicKey{
 		Type:       data.KeyTypeRSASSA_PSS_SHA256,
-		Scheme:     data.KeySchemeRSASSA_PSS_SHA256,
+		Scheme:     s.keyScheme(),
 		Algorithms: data.HashAlgorithms,
 		Value:      keyValBytes,
 	}
@@ -114,25 +168,121 @@ func (s *rsaSigner) PublicData() *data.PublicKey {
 
 func (s *rsaSigner) SignMessage(message []byte) ([]byte, error) {
 	hash := sha256.Sum256(message)
-	return rsa.SignPSS(rand.Reader, s.PrivateKey, crypto.SHA256, hash[:], &rsa.PSSOptions{})
+	return s.signDigest(hash[:])
+}
+
+func (s *rsaSigner) SignReader(r io.Reader) ([]byte, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return s.signDigest(h.Sum(nil))
+}
+
+// signDigest signs a SHA-256 digest with the scheme of the key.
+func (s *rsaSigner) signDigest(digest []byte) ([]byte, error) {
+	if s.keyScheme() == data.KeySchemeRSA_PKCS1v15_SHA256 {
+		return rsa.SignPKCS1v15(rand.Reader, s.PrivateKey, crypto.SHA256, digest)
+	}
+	return rsa.SignPSS(rand.Reader, s.PrivateKey, crypto.SHA256, digest, &rsa.PSSOptions{})
+}
+
+func (s *rsaSigner) digest(message []byte) ([]byte, error) {
+	hash := sha256.Sum256(message)
+	return hash[:], nil
+}
+
+// deterministic reports false for RSASSA-PSS, whose signatures use a random
+// salt, and true for PKCS #1 v1.5.
+func (s *rsaSigner) deterministic() bool {
+	return s.keyScheme() == data.KeySchemeRSA_PKCS1v15_SHA256
 }
 
 func (s *rsaSigner) ContainsID(id string) bool {
 	return s.PublicData().ContainsID(id)
 }
 
+// rsaPrivateKeyValue is the key value of RSA private keys, the public key as
+// in rsaPublic along with the PEM encoded PKCS#1 private key.
+type rsaPrivateKeyValue struct {
+	PublicKey  string `json:"public"`
+	PrivateKey string `json:"private"`
+}
+
 func (s *rsaSigner) MarshalPrivateKey() (*data.PrivateKey, error) {
-	return nil, errors.New("not implemented for test")
+	var public rsaPublic
+	if err := json.Unmarshal(s.PublicData().Value, &public); err != nil {
+		return nil, err
+	}
+	private := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(s.PrivateKey),
+	})
+	valueBytes, err := json.Marshal(rsaPrivateKeyValue{
+		PublicKey:  public.PublicKey,
+		PrivateKey: string(private),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &data.PrivateKey{
+		Type:       data.KeyTypeRSASSA_PSS_SHA256,
+		Scheme:     s.keyScheme(),
+		Algorithms: data.HashAlgorithms,
+		Value:      valueBytes,
+	}, nil
 }
 
 func (s *rsaSigner) UnmarshalPrivateKey(key *data.PrivateKey) error {
-	return errors.New("not implemented for test")
+	if key.Scheme != "" && key.Scheme != data.KeySchemeRSASSA_PSS_SHA256 && key.Scheme != data.KeySchemeRSA_PKCS1v15_SHA256 {
+		return fmt.Errorf("tuf: unsupported rsa scheme %q", key.Scheme)
+	}
+	keyValue := &rsaPrivateKeyValue{}
+	if err := json.Unmarshal(key.Value, keyValue); err != nil {
+		return err
+	}
+	block, _ := pem.Decode([]byte(keyValue.PrivateKey))
+	if block == nil {
+		return errors.New("tuf: pem decoding private key failed")
+	}
+	privkey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("tuf: error unmarshalling rsa private key: %w", err)
+	}
+
+	// Make sure the provided public key matches the private key.
+	if keyValue.PublicKey == "" {
+		return ErrMissingPublicKey
+	}
+	pub, err := parseKey(keyValue.PublicKey)
+	if err != nil {
+		return err
+	}
+	if !pub.Equal(&privkey.PublicKey) {
+		return errors.New("tuf: rsa public and private keys do not match")
+	}
+
+	*s = rsaSigner{PrivateKey: privkey, scheme: key.Scheme}
+	return nil
 }
 
 func GenerateRsaKey() (*rsaSigner, error) {
-	privkey, err := rsa.GenerateKey(rand.Reader, 2048)
+	return GenerateRsaKeyWithScheme(data.KeySchemeRSASSA_PSS_SHA256)
+}
+
+// GenerateRsaKeyWithScheme generates a new RSA key signing with scheme,
+// either RSASSA-PSS or PKCS #1 v1.5 with SHA-256.
+func GenerateRsaKeyWithScheme(scheme string) (*rsaSigner, error) {
+	if scheme != data.KeySchemeRSASSA_PSS_SHA256 && scheme != data.KeySchemeRSA_PKCS1v15_SHA256 {
+		return nil, fmt.Errorf("tuf: unsupported rsa scheme %q", scheme)
+	}
+	return generateRsaKey(scheme, rand.Reader)
+}
+
+func generateRsaKey(scheme string, r io.Reader) (*rsaSigner, error) {
+	privkey, err := rsa.GenerateKey(r, 2048)
 	if err != nil {
 		return nil, err
 	}
-	return &rsaSigner{privkey}, nil
+	return &rsaSigner{PrivateKey: privkey, scheme: scheme}, nil
 }
@@ -1,6 +1,9 @@
 package keys
 
 import (
+	"encoding/json"
+
+	"github.com/theupdateframework/go-tuf/data"
 	. "gopkg.in/check.v1"
 )
 
@@ -28,3 +31,65 @@ func (RsaSuite) TestMarshalUnmarshal(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(pubKey.MarshalPublicKey(), DeepEquals, publicData)
 }
+
+func (RsaSuite) TestSignVerifyPKCS1v15(c *C) {
+	signer, err := GenerateRsaKeyWithScheme(data.KeySchemeRSA_PKCS1v15_SHA256)
+	c.Assert(err, IsNil)
+	publicData := signer.PublicData()
+	c.Assert(publicData.Scheme, Equals, data.KeySchemeRSA_PKCS1v15_SHA256)
+	pubKey, err := GetVerifier(publicData)
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(pubKey.Verify(msg, sig), IsNil)
+	c.Assert(pubKey.Verify([]byte("bar"), sig), NotNil)
+
+	// The signature is not a valid RSASSA-PSS signature.
+	publicData.Scheme = data.KeySchemeRSASSA_PSS_SHA256
+	pss, err := GetVerifier(publicData)
+	c.Assert(err, IsNil)
+	c.Assert(pss.Verify(msg, sig), NotNil)
+
+	_, err = GenerateRsaKeyWithScheme("rsa-unknown")
+	c.Assert(err, ErrorMatches, `tuf: unsupported rsa scheme "rsa-unknown"`)
+}
+
+func (RsaSuite) TestMarshalUnmarshalPrivateKey(c *C) {
+	for _, scheme := range []string{data.KeySchemeRSASSA_PSS_SHA256, data.KeySchemeRSA_PKCS1v15_SHA256} {
+		signer, err := GenerateRsaKeyWithScheme(scheme)
+		c.Assert(err, IsNil)
+		privKey, err := signer.MarshalPrivateKey()
+		c.Assert(err, IsNil)
+		c.Assert(privKey.Scheme, Equals, scheme)
+
+		unmarshalled, err := GetSigner(privKey)
+		c.Assert(err, IsNil)
+		c.Assert(unmarshalled.PublicData(), DeepEquals, signer.PublicData())
+		msg := []byte("foo")
+		sig, err := unmarshalled.SignMessage(msg)
+		c.Assert(err, IsNil)
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+		c.Assert(verifier.Verify(msg, sig), IsNil)
+	}
+
+	// The public key must match the private key.
+	signer, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	other, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	privKey, err := signer.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	otherKey, err := other.MarshalPrivateKey()
+	c.Assert(err, IsNil)
+	var value, otherValue rsaPrivateKeyValue
+	c.Assert(json.Unmarshal(privKey.Value, &value), IsNil)
+	c.Assert(json.Unmarshal(otherKey.Value, &otherValue), IsNil)
+	value.PublicKey = otherValue.PublicKey
+	privKey.Value, err = json.Marshal(value)
+	c.Assert(err, IsNil)
+	_, err = GetSigner(privKey)
+	c.Assert(err, ErrorMatches, ".*rsa public and private keys do not match")
+}
@@ -0,0 +1,97 @@
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// keySchemes stores mapping between key type strings and the signature
+// schemes usable with keys of that type, in order of preference.
+var keySchemes sync.Map
+
+// RegisterKeySchemes registers the signature schemes usable with keys of
+// the given type, in order of preference. It replaces any schemes
+// previously registered for keyType.
+func RegisterKeySchemes(keyType string, schemes ...string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	keySchemes.Store(keyType, append([]string(nil), schemes...))
+}
+
+// CandidateSchemes returns the registered signature schemes that can verify
+// signatures by pk, in order of preference. Verifiers that do not know which
+// scheme produced a signature can try each of them in turn.
+//
+// Generic "ecdsa" keys can be used with any registered ECDSA scheme: the
+// auto-detected NIST curves come first, followed by the other registered
+// ECDSA schemes in lexical order.
+func CandidateSchemes(pk *data.PublicKey) ([]string, error) {
+	if _, ok := VerifierMap.Load(pk.Type); !ok {
+		return nil, ErrUnsupportedKeyType
+	}
+	if pk.Type == data.KeyTypeECDSA {
+		return ecdsaCandidateSchemes(), nil
+	}
+	if schemes, ok := keySchemes.Load(pk.Type); ok {
+		return append([]string(nil), schemes.([]string)...), nil
+	}
+	// A verifier registered without any scheme can only be assumed to
+	// support the scheme of the key itself.
+	if pk.Scheme == "" {
+		return nil, nil
+	}
+	return []string{pk.Scheme}, nil
+}
+
+func ecdsaCandidateSchemes() []string {
+	var schemes []string
+	seen := make(map[string]bool)
+	for _, keyType := range ecdsaAutoDetectKeyTypes {
+		if params, err := getEcdsaParams(keyType); err == nil && !seen[params.scheme] {
+			schemes = append(schemes, params.scheme)
+			seen[params.scheme] = true
+		}
+	}
+	var others []string
+	ecdsaKeyTypes.Range(func(_, v interface{}) bool {
+		if scheme := v.(*ecdsaParams).scheme; !seen[scheme] {
+			others = append(others, scheme)
+			seen[scheme] = true
+		}
+		return true
+	})
+	sort.Strings(others)
+	return append(schemes, others...)
+}
+
+// Key families returned by KeyFamily.
+const (
+	KeyFamilyEd25519 = "ed25519"
+	KeyFamilyECDSA   = "ecdsa"
+	KeyFamilyRSA     = "rsa"
+)
+
+// KeyFamily returns the family of signature algorithms of the key of v,
+// whatever its curve, hash function or signature variant: KeyFamilyEd25519
+// for pure Ed25519, Ed25519ctx and Ed25519ph keys, KeyFamilyECDSA for ECDSA
+// keys on any curve and KeyFamilyRSA for RSA keys. Keys of custom types are
+// their own family, named after their key type. It fails with
+// ErrUnsupportedKeyType for custom verifiers without a public key.
+func KeyFamily(v Verifier) (string, error) {
+	switch v.(type) {
+	case *ed25519Verifier, *ed25519ctxVerifier, *ed25519phVerifier:
+		return KeyFamilyEd25519, nil
+	case *ecdsaVerifier:
+		return KeyFamilyECDSA, nil
+	case *rsaVerifier:
+		return KeyFamilyRSA, nil
+	}
+	pk := v.MarshalPublicKey()
+	if pk == nil {
+		return "", fmt.Errorf("%w: verifier has no public key", ErrUnsupportedKeyType)
+	}
+	return pk.Type, nil
+}
@@ -0,0 +1,121 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type SchemesSuite struct{}
+
+var _ = Suite(&SchemesSuite{})
+
+func (SchemesSuite) TestCandidateSchemes(c *C) {
+	rsaKey, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	schemes, err := CandidateSchemes(rsaKey.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(schemes, DeepEquals, []string{data.KeySchemeRSASSA_PSS_SHA256, data.KeySchemeRSA_PKCS1v15_SHA256})
+
+	edKey, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	schemes, err = CandidateSchemes(edKey.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(schemes, DeepEquals, []string{data.KeySchemeEd25519})
+
+	ecdsaKey, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	c.Assert(err, IsNil)
+	schemes, err = CandidateSchemes(ecdsaKey.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(schemes, DeepEquals, []string{data.KeySchemeECDSA_SHA2_P384})
+
+	schemes, err = CandidateSchemes(&data.PublicKey{Type: data.KeyTypeECDSA})
+	c.Assert(err, IsNil)
+	c.Assert(len(schemes) >= 3, Equals, true)
+	c.Assert(schemes[:3], DeepEquals, []string{data.KeySchemeECDSA_SHA2_P256, data.KeySchemeECDSA_SHA2_P384, data.KeySchemeECDSA_SHA2_P521})
+
+	_, err = CandidateSchemes(&data.PublicKey{Type: "unknown"})
+	c.Assert(err, Equals, ErrUnsupportedKeyType)
+}
+
+func (SchemesSuite) TestCandidateSchemesCustomType(c *C) {
+	defer SnapshotRegistry()()
+
+	VerifierMap.Store("custom", NewP256Verifier)
+	schemes, err := CandidateSchemes(&data.PublicKey{Type: "custom", Scheme: "custom-scheme"})
+	c.Assert(err, IsNil)
+	c.Assert(schemes, DeepEquals, []string{"custom-scheme"})
+
+	RegisterKeySchemes("custom", "a", "b")
+	schemes, err = CandidateSchemes(&data.PublicKey{Type: "custom", Scheme: "custom-scheme"})
+	c.Assert(err, IsNil)
+	c.Assert(schemes, DeepEquals, []string{"a", "b"})
+
+	// The returned slice is a copy.
+	schemes[0] = "z"
+	schemes, err = CandidateSchemes(&data.PublicKey{Type: "custom"})
+	c.Assert(err, IsNil)
+	c.Assert(schemes, DeepEquals, []string{"a", "b"})
+}
+
+func (SchemesSuite) TestRsaPKCS1v15Scheme(c *C) {
+	signer, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	msg := []byte("foo")
+	digest := sha256.Sum256(msg)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, signer.PrivateKey, crypto.SHA256, digest[:])
+	c.Assert(err, IsNil)
+
+	pk := signer.PublicData()
+	pss, err := GetVerifier(pk)
+	c.Assert(err, IsNil)
+	c.Assert(pss.Verify(msg, sig), NotNil)
+
+	pk.Scheme = data.KeySchemeRSA_PKCS1v15_SHA256
+	pkcs1, err := GetVerifier(pk)
+	c.Assert(err, IsNil)
+	c.Assert(pkcs1.Verify(msg, sig), IsNil)
+}
+
+func (SchemesSuite) TestKeyFamily(c *C) {
+	family := func(v Verifier) string {
+		f, err := KeyFamily(v)
+		c.Assert(err, IsNil)
+		return f
+	}
+
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	edVerifier, err := GetVerifier(ed.PublicData())
+	c.Assert(err, IsNil)
+	phVerifier, err := NewEd25519phVerifier(edVerifier)
+	c.Assert(err, IsNil)
+	c.Assert(family(edVerifier), Equals, KeyFamilyEd25519)
+	c.Assert(family(phVerifier), Equals, KeyFamilyEd25519)
+
+	for _, keyType := range []string{data.KeyTypeECDSA_SHA2_P256, data.KeyTypeECDSA_SHA2_P384} {
+		ec, err := GenerateEcdsaKeyWithType(keyType)
+		c.Assert(err, IsNil)
+		verifier, err := GetVerifier(ec.PublicData())
+		c.Assert(err, IsNil)
+		c.Assert(family(verifier), Equals, KeyFamilyECDSA)
+	}
+
+	rsaKey, err := GenerateRsaKeyWithScheme(data.KeySchemeRSA_PKCS1v15_SHA256)
+	c.Assert(err, IsNil)
+	rsaVerifier, err := GetVerifier(rsaKey.PublicData())
+	c.Assert(err, IsNil)
+	c.Assert(family(rsaVerifier), Equals, KeyFamilyRSA)
+
+	// Wrapped and custom verifiers are named after their key type.
+	c.Assert(family(&countingVerifier{Verifier: edVerifier}), Equals, data.KeyTypeEd25519)
+
+	// Custom verifiers without a public key have no family.
+	_, err = KeyFamily(nilKeyVerifier{edVerifier})
+	c.Assert(errors.Is(err, ErrUnsupportedKeyType), Equals, true)
+}
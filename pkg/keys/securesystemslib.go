@@ -0,0 +1,107 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// securesystemslibKey is a public key object as serialized by python's
+// securesystemslib, whose key values are strings: hex for ed25519 keys and
+// PEM encoded PKIX for ECDSA and RSA keys.
+type securesystemslibKey struct {
+	Type       string   `json:"keytype"`
+	Scheme     string   `json:"scheme"`
+	Algorithms []string `json:"keyid_hash_algorithms,omitempty"`
+	Value      struct {
+		Public string `json:"public"`
+	} `json:"keyval"`
+}
+
+// FromSecuresystemslibKey parses a public key object produced by python's
+// securesystemslib, as found in python-tuf repositories, and converts it to
+// the representation of this package, keeping its key type, scheme and key
+// ID hash algorithms.
+//
+// The public key value is kept as is, hex for ed25519 keys and PEM for
+// ECDSA and RSA keys, which the verifiers of this package all accept, so
+// the key IDs are the ones python-tuf computes and signatures from python
+// repositories refer to.
+func FromSecuresystemslibKey(raw []byte) (*data.PublicKey, error) {
+	var k securesystemslibKey
+	if err := json.Unmarshal(raw, &k); err != nil {
+		return nil, err
+	}
+	if k.Type == "" {
+		return nil, errors.New("tuf: key is missing keytype")
+	}
+	if k.Value.Public == "" {
+		return nil, errors.New("tuf: key is missing keyval.public")
+	}
+	if k.Type != data.KeyTypeRSASSA_PSS_SHA256 && !strings.HasPrefix(strings.TrimSpace(k.Value.Public), "-----BEGIN") {
+		if _, err := hex.DecodeString(k.Value.Public); err != nil {
+			return nil, fmt.Errorf("%w: public key is neither PEM nor hex", ErrInvalidKey)
+		}
+	}
+
+	valueBytes, err := json.Marshal(k.Value)
+	if err != nil {
+		return nil, err
+	}
+	pk := &data.PublicKey{
+		Type:       k.Type,
+		Scheme:     k.Scheme,
+		Algorithms: k.Algorithms,
+		Value:      valueBytes,
+	}
+
+	// Make sure the converted key is usable, which also checks that the
+	// declared type and scheme match the key.
+	if _, err := GetVerifier(pk); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+// ToSecuresystemslibKey returns the JSON encoding of pk in the form of
+// python's securesystemslib, for use in metadata read by python-tuf: hex for
+// ed25519 key values and PEM encoded PKIX for ECDSA and RSA ones. The key
+// type, scheme and key ID hash algorithms of pk are kept. The canonical form
+// of the result is the one securesystemslib computes key IDs over.
+//
+// It is the inverse of FromSecuresystemslibKey. The key IDs of ECDSA keys
+// with hex points differ between both forms, and so do the ones of RSA keys
+// whose value is not already a PKIX "PUBLIC KEY" PEM block.
+func ToSecuresystemslibKey(pk *data.PublicKey) ([]byte, error) {
+	verifier, err := GetVerifier(pk)
+	if err != nil {
+		return nil, err
+	}
+	k := securesystemslibKey{
+		Type:       pk.Type,
+		Scheme:     pk.Scheme,
+		Algorithms: pk.Algorithms,
+	}
+	switch pub := cryptoPublicKey(verifier).(type) {
+	case ed25519.PublicKey:
+		k.Value.Public = hex.EncodeToString(pub)
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, err)
+		}
+		k.Value.Public = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+	return json.Marshal(k)
+}
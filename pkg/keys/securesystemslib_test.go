@@ -0,0 +1,193 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type SecuresystemslibSuite struct{}
+
+var _ = Suite(&SecuresystemslibSuite{})
+
+func (SecuresystemslibSuite) TestEd25519FromPythonTUF(c *C) {
+	// Root metadata generated by python-tuf, signed by its root key.
+	b, err := os.ReadFile("../../client/python_interop/testdata/python-tuf-v0.11.1/with-consistent-snapshot/repository/metadata/root.json")
+	c.Assert(err, IsNil)
+	var root struct {
+		Signatures []data.Signature `json:"signatures"`
+		Signed     json.RawMessage  `json:"signed"`
+	}
+	c.Assert(json.Unmarshal(b, &root), IsNil)
+	var signed struct {
+		Keys map[string]json.RawMessage `json:"keys"`
+	}
+	c.Assert(json.Unmarshal(root.Signed, &signed), IsNil)
+	msg, err := cjson.EncodeCanonical(json.RawMessage(root.Signed))
+	c.Assert(err, IsNil)
+
+	sig := root.Signatures[0]
+	pk, err := FromSecuresystemslibKey(signed.Keys[sig.KeyID])
+	c.Assert(err, IsNil)
+	c.Assert(pk.Type, Equals, data.KeyTypeEd25519)
+	c.Assert(pk.IDs()[0], Equals, sig.KeyID)
+
+	verifier, err := GetVerifier(pk)
+	c.Assert(err, IsNil)
+	c.Assert(verifier.Verify(msg, sig.Signature), IsNil)
+}
+
+const securesystemslibEcdsaKey = `{
+  "keytype": "ecdsa",
+  "scheme": "ecdsa-sha2-nistp256",
+  "keyid_hash_algorithms": ["sha256", "sha512"],
+  "keyval": {
+    "public": "-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEfFcbLviimH7CLG2wO46B/6aDbzGJ\nc9aM5DI/ypVHMVxxqZCqpiatbNZ5yQKlGjpjk+zDm8+Tlq0SSJ61Ksq9Dw==\n-----END PUBLIC KEY-----\n"
+  }
+}`
+
+const securesystemslibRsaKey = `{
+  "keytype": "rsa",
+  "scheme": "rsassa-pss-sha256",
+  "keyid_hash_algorithms": ["sha256", "sha512"],
+  "keyval": {
+    "public": "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAn5h187EbNSxUu80UqFkS\nYSpvIF54GgWLmaJcfdyPQKQenB5gXlL++5vwsQXb/STnWBAbAa9o9LsyT9w/4W+W\nlilLd/iJrvFkfeMSArNQGqh+eB/bSnlXHSj9Bkxv963b6wB8PlU5cxyNpeHVkfmg\noPtY0zgvkoEDvZMZSC1jJM5mD9z4PG667LXvXY1XuAMWiVrqgw1LbWuIHHhGlLHd\nymu0Y04EICbG49dzG1uMH54Rs9wpsgeo36v0fOyic3/iqsjv0vNmUtUk1Qir5O4a\nPCtPUVByHPudE9U3QJ2df2drBbwSA5VEhT3XlmeJJMonK5kfFvnQ5M7SfaFfyFwk\nKwIDAQAB\n-----END PUBLIC KEY-----\n"
+  }
+}`
+
+func (SecuresystemslibSuite) TestPEMKeys(c *C) {
+	// Signatures of "foo" made with OpenSSL by the keys above.
+	for _, t := range []struct {
+		raw string
+		sig string
+	}{
+		{securesystemslibEcdsaKey, "3046022100f12f6557a64bd53f56ba20530b29d50931c2b21d70c356c021337113b994fb5e022100f374cacb29ce428da01f4bd94ff51083906c3378fcbd5c8048ba46bfd4e6b3d6"},
+		{securesystemslibRsaKey, "76b066177963e0e5ac79508fc9c494d6d45f13249cd01c77e285aa7aba358c8c0f43b372bb426d5b5cde76b1ef240ce4440188476bf64b3f6e1e1c70eccb4befc4fde99594098dde6bb6e4bb123aa59ce5509565b38a5984e6c88d0d181c1a9055c7a0024254dc3dee0b3efd75b8fa2cecb650cf1684e4e1bcbda0d4de79be6421de85d00a9e2ff00ec2fc68c09a19ab982438ac89722e6efcc669581344bb81980340e7fa827425a2355cc109ca08285496b107ee4a1ca80c4d8d55c0d8a4d2d62e8cb88818e9fe50b6a8c5bb20aa3ffa0bc963b10cec32b97a55b59317ab5b0f13a82cb4dfd95adca355c96bbfff716fcd570cc0716cb1cd8f550bd3120133"},
+	} {
+		var original securesystemslibKey
+		c.Assert(json.Unmarshal([]byte(t.raw), &original), IsNil)
+
+		pk, err := FromSecuresystemslibKey([]byte(t.raw))
+		c.Assert(err, IsNil)
+		c.Assert(pk.Type, Equals, original.Type)
+		c.Assert(pk.Scheme, Equals, original.Scheme)
+		c.Assert(pk.Algorithms, DeepEquals, original.Algorithms)
+
+		// The key ID is the one python-tuf computes, over the canonical
+		// form of the original key object.
+		canonical, err := cjson.EncodeCanonical(json.RawMessage(t.raw))
+		c.Assert(err, IsNil)
+		sum := sha256.Sum256(canonical)
+		c.Assert(pk.IDs(), DeepEquals, []string{hex.EncodeToString(sum[:])})
+
+		verifier, err := GetVerifier(pk)
+		c.Assert(err, IsNil)
+		c.Assert(verifier.Verify([]byte("foo"), mustHex(c, t.sig)), IsNil)
+		c.Assert(verifier.Verify([]byte("bar"), mustHex(c, t.sig)), NotNil)
+	}
+}
+
+func (SecuresystemslibSuite) TestInvalidKeys(c *C) {
+	for _, raw := range []string{
+		`{`,
+		`{"keyval": {"public": "00"}}`,
+		`{"keytype": "ed25519", "scheme": "ed25519", "keyval": {}}`,
+		`{"keytype": "ed25519", "scheme": "ed25519", "keyval": {"public": "zz"}}`,
+		`{"keytype": "ed25519", "scheme": "ed25519", "keyval": {"public": "00"}}`,
+		`{"keytype": "ecdsa", "scheme": "ecdsa-sha2-nistp256", "keyval": {"public": "-----BEGIN PUBLIC KEY-----\nfoo\n"}}`,
+		`{"keytype": "foo", "scheme": "foo", "keyval": {"public": "00"}}`,
+	} {
+		_, err := FromSecuresystemslibKey([]byte(raw))
+		c.Assert(err, NotNil, Commentf("%s", raw))
+	}
+
+	// The scheme does not match the curve of the key.
+	var k map[string]interface{}
+	c.Assert(json.Unmarshal([]byte(securesystemslibEcdsaKey), &k), IsNil)
+	k["scheme"] = data.KeySchemeECDSA_SHA2_P384
+	raw, err := json.Marshal(k)
+	c.Assert(err, IsNil)
+	_, err = FromSecuresystemslibKey(raw)
+	c.Assert(errors.Is(err, ErrKeyMismatch), Equals, true)
+
+	// The PEM block does not hold an ECDSA key.
+	var rsaKey map[string]interface{}
+	c.Assert(json.Unmarshal([]byte(securesystemslibRsaKey), &rsaKey), IsNil)
+	k["scheme"] = data.KeySchemeECDSA_SHA2_P256
+	k["keyval"] = rsaKey["keyval"]
+	raw, err = json.Marshal(k)
+	c.Assert(err, IsNil)
+	_, err = FromSecuresystemslibKey(raw)
+	c.Assert(errors.Is(err, ErrKeyMismatch), Equals, true)
+}
+
+func (SecuresystemslibSuite) TestToSecuresystemslibKey(c *C) {
+	// The reference key objects are reproduced byte for byte in canonical
+	// form.
+	b, err := os.ReadFile("../../client/python_interop/testdata/python-tuf-v0.11.1/with-consistent-snapshot/repository/metadata/root.json")
+	c.Assert(err, IsNil)
+	var root struct {
+		Signatures []data.Signature `json:"signatures"`
+		Signed     struct {
+			Keys map[string]json.RawMessage `json:"keys"`
+		} `json:"signed"`
+	}
+	c.Assert(json.Unmarshal(b, &root), IsNil)
+	keyID := root.Signatures[0].KeyID
+
+	for _, raw := range []string{string(root.Signed.Keys[keyID]), securesystemslibEcdsaKey, securesystemslibRsaKey} {
+		expected, err := cjson.EncodeCanonical(json.RawMessage(raw))
+		c.Assert(err, IsNil)
+		pk, err := FromSecuresystemslibKey([]byte(raw))
+		c.Assert(err, IsNil)
+		out, err := ToSecuresystemslibKey(pk)
+		c.Assert(err, IsNil)
+		canonical, err := cjson.EncodeCanonical(json.RawMessage(out))
+		c.Assert(err, IsNil)
+		c.Assert(string(canonical), Equals, string(expected))
+	}
+
+	// python-tuf computes key IDs over the canonical key object.
+	out, err := ToSecuresystemslibKey(mustFromSecuresystemslibKey(c, root.Signed.Keys[keyID]))
+	c.Assert(err, IsNil)
+	canonical, err := cjson.EncodeCanonical(json.RawMessage(out))
+	c.Assert(err, IsNil)
+	sum := sha256.Sum256(canonical)
+	c.Assert(hex.EncodeToString(sum[:]), Equals, keyID)
+
+	// Keys of this package round trip through both forms. ECDSA keys are
+	// written as PEM, so only ed25519 keys keep their key IDs.
+	for _, gen := range []func() (Signer, error){
+		func() (Signer, error) { return GenerateEd25519Key() },
+		func() (Signer, error) { return GenerateEcdsaKey() },
+	} {
+		signer, err := gen()
+		c.Assert(err, IsNil)
+		out, err := ToSecuresystemslibKey(signer.PublicData())
+		c.Assert(err, IsNil)
+		pk := mustFromSecuresystemslibKey(c, out)
+		verifier, err := GetVerifier(pk)
+		c.Assert(err, IsNil)
+		original, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+		c.Assert(verifier.Public(), Equals, original.Public())
+		if signer.PublicData().Type == data.KeyTypeEd25519 {
+			c.Assert(pk.IDs(), DeepEquals, signer.PublicData().IDs())
+		}
+	}
+
+	_, err = ToSecuresystemslibKey(&data.PublicKey{Type: "foo", Value: []byte(`{}`)})
+	c.Assert(err, NotNil)
+}
+
+func mustFromSecuresystemslibKey(c *C, raw []byte) *data.PublicKey {
+	pk, err := FromSecuresystemslibKey(raw)
+	c.Assert(err, IsNil)
+	return pk
+}
@@ -0,0 +1,91 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrSecurityLevelTooLow is returned by SecurityLevelVerifier for keys whose
+// security level is below the configured minimum.
+var ErrSecurityLevelTooLow = errors.New("tuf: key security level too low")
+
+// A SecurityLevelVerifier verifies signatures only with keys providing at
+// least a minimum security level, in bits, such as required by compliance
+// policies.
+type SecurityLevelVerifier struct {
+	minBits int
+}
+
+// NewSecurityLevelVerifier returns a SecurityLevelVerifier refusing keys with
+// a security level below minBits, as computed by SecurityLevel.
+func NewSecurityLevelVerifier(minBits int) *SecurityLevelVerifier {
+	return &SecurityLevelVerifier{minBits: minBits}
+}
+
+// Verify verifies sig over msg with key like Verifier.Verify, after checking
+// that the security level of key is at least the minimum of v. It returns
+// ErrSecurityLevelTooLow for weaker keys, without verifying the signature.
+func (v *SecurityLevelVerifier) Verify(key *data.PublicKey, msg, sig []byte) error {
+	verifier, err := GetVerifier(key)
+	if err != nil {
+		return err
+	}
+	bits, err := verifierSecurityLevel(verifier)
+	if err != nil {
+		return err
+	}
+	if bits < v.minBits {
+		return fmt.Errorf("%w: %s key provides %d bits, policy requires %d", ErrSecurityLevelTooLow, key.Type, bits, v.minBits)
+	}
+	return verifier.Verify(msg, sig)
+}
+
+// SecurityLevel returns the security level of key, in bits, following NIST
+// SP 800-57 part 1: 128 bits for ed25519, half the curve size for ECDSA,
+// capped by the collision resistance of the hash of the key scheme, and the
+// level of the modulus size for RSA, such as 112 bits for 2048-bit keys and
+// 128 bits for 3072-bit keys. Custom key types fail with
+// ErrUnsupportedKeyType.
+func SecurityLevel(key *data.PublicKey) (int, error) {
+	verifier, err := GetVerifier(key)
+	if err != nil {
+		return 0, err
+	}
+	return verifierSecurityLevel(verifier)
+}
+
+// rsaSecurityLevels lists the security levels of RSA moduli of at least the
+// given sizes, from NIST SP 800-57 part 1, table 2.
+var rsaSecurityLevels = []struct {
+	modulusBits, bits int
+}{
+	{15360, 256},
+	{7680, 192},
+	{3072, 128},
+	{2048, 112},
+	{1024, 80},
+}
+
+func verifierSecurityLevel(v Verifier) (int, error) {
+	switch k := v.(type) {
+	case *ed25519Verifier:
+		return 128, nil
+	case *ecdsaVerifier:
+		bits := k.params.curve.Params().BitSize / 2
+		if hashBits := k.params.hash.Size() * 8 / 2; hashBits < bits {
+			bits = hashBits
+		}
+		return bits, nil
+	case *rsaVerifier:
+		n := k.rsaKey.N.BitLen()
+		for _, l := range rsaSecurityLevels {
+			if n >= l.modulusBits {
+				return l.bits, nil
+			}
+		}
+		return 0, nil
+	}
+	return 0, ErrUnsupportedKeyType
+}
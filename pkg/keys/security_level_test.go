@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type SecurityLevelSuite struct{}
+
+var _ = Suite(&SecurityLevelSuite{})
+
+func (SecurityLevelSuite) TestSecurityLevel(c *C) {
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	p256, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	p384, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	c.Assert(err, IsNil)
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+
+	for _, t := range []struct {
+		signer Signer
+		bits   int
+	}{
+		{ed, 128},
+		{p256, 128},
+		{p384, 192},
+		{rsa, 112},
+	} {
+		bits, err := SecurityLevel(t.signer.PublicData())
+		c.Assert(err, IsNil)
+		c.Assert(bits, Equals, t.bits, Commentf("%s", t.signer.PublicData().Type))
+	}
+
+	_, err = SecurityLevel(&data.PublicKey{Type: "foo"})
+	c.Assert(err, NotNil)
+}
+
+func (SecurityLevelSuite) TestSecurityLevelVerifier(c *C) {
+	msg := []byte("foo")
+	v := NewSecurityLevelVerifier(192)
+
+	p256, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	sig, err := p256.SignMessage(msg)
+	c.Assert(err, IsNil)
+	err = v.Verify(p256.PublicData(), msg, sig)
+	c.Assert(errors.Is(err, ErrSecurityLevelTooLow), Equals, true)
+	c.Assert(NewSecurityLevelVerifier(128).Verify(p256.PublicData(), msg, sig), IsNil)
+
+	p384, err := GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	c.Assert(err, IsNil)
+	sig, err = p384.SignMessage(msg)
+	c.Assert(err, IsNil)
+	c.Assert(v.Verify(p384.PublicData(), msg, sig), IsNil)
+	c.Assert(v.Verify(p384.PublicData(), []byte("bar"), sig), NotNil)
+
+	ed, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	sig, err = ed.SignMessage(msg)
+	c.Assert(err, IsNil)
+	err = v.Verify(ed.PublicData(), msg, sig)
+	c.Assert(errors.Is(err, ErrSecurityLevelTooLow), Equals, true)
+}
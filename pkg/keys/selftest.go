@@ -0,0 +1,136 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// builtinAlgorithms lists the key types supported by go-tuf without build
+// tags.
+var builtinAlgorithms = []string{
+	data.KeyTypeEd25519,
+	data.KeyTypeECDSA,
+	data.KeyTypeECDSA_SHA2_P256,
+	data.KeyTypeECDSA_SHA2_P384,
+	data.KeyTypeECDSA_SHA2_P521,
+	data.KeyTypeECDSA_SHA3_P256,
+	data.KeyTypeRSASSA_PSS_SHA256,
+}
+
+// Known answer test vectors. The ed25519 vector is test 1 of RFC 8032, the
+// ECDSA and RSA-PSS signatures of "foo" were made with OpenSSL.
+const (
+	selfTestEd25519Seed = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+	selfTestEd25519Sig  = "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+
+	selfTestEcdsaPoint = "047c571b2ef8a2987ec22c6db03b8e81ffa6836f318973d68ce4323fca9547315c71a990aaa626ad6cd679c902a51a3a6393ecc39bcf9396ad12489eb52acabd0f"
+	selfTestEcdsaSig   = "3046022100f12f6557a64bd53f56ba20530b29d50931c2b21d70c356c021337113b994fb5e022100f374cacb29ce428da01f4bd94ff51083906c3378fcbd5c8048ba46bfd4e6b3d6"
+
+	selfTestRsaKey = `-----BEGIN PUBLIC KEY-----
+MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAn5h187EbNSxUu80UqFkS
+YSpvIF54GgWLmaJcfdyPQKQenB5gXlL++5vwsQXb/STnWBAbAa9o9LsyT9w/4W+W
+lilLd/iJrvFkfeMSArNQGqh+eB/bSnlXHSj9Bkxv963b6wB8PlU5cxyNpeHVkfmg
+oPtY0zgvkoEDvZMZSC1jJM5mD9z4PG667LXvXY1XuAMWiVrqgw1LbWuIHHhGlLHd
+ymu0Y04EICbG49dzG1uMH54Rs9wpsgeo36v0fOyic3/iqsjv0vNmUtUk1Qir5O4a
+PCtPUVByHPudE9U3QJ2df2drBbwSA5VEhT3XlmeJJMonK5kfFvnQ5M7SfaFfyFwk
+KwIDAQAB
+-----END PUBLIC KEY-----
+`
+	selfTestRsaSig = "76b066177963e0e5ac79508fc9c494d6d45f13249cd01c77e285aa7aba358c8c0f43b372bb426d5b5cde76b1ef240ce4440188476bf64b3f6e1e1c70eccb4befc4fde99594098dde6bb6e4bb123aa59ce5509565b38a5984e6c88d0d181c1a9055c7a0024254dc3dee0b3efd75b8fa2cecb650cf1684e4e1bcbda0d4de79be6421de85d00a9e2ff00ec2fc68c09a19ab982438ac89722e6efcc669581344bb81980340e7fa827425a2355cc109ca08285496b107ee4a1ca80c4d8d55c0d8a4d2d62e8cb88818e9fe50b6a8c5bb20aa3ffa0bc963b10cec32b97a55b59317ab5b0f13a82cb4dfd95adca355c96bbfff716fcd570cc0716cb1cd8f550bd3120133"
+)
+
+// SelfTest runs known answer tests of signing and verification with the
+// built-in algorithms, through the registry, and returns the first failure.
+func SelfTest() error {
+	edPriv := ed25519.NewKeyFromSeed(mustDecodeHex(selfTestEd25519Seed))
+	edPub := edPriv.Public().(ed25519.PublicKey)
+	edSigner := NewEd25519Signer(Ed25519PrivateKeyValue{
+		Public:  data.HexBytes(edPub),
+		Private: data.HexBytes(edPriv),
+	})
+	edSig, err := edSigner.SignMessage(nil)
+	if err != nil {
+		return fmt.Errorf("tuf: ed25519 self-test: %w", err)
+	}
+	if !bytes.Equal(edSig, mustDecodeHex(selfTestEd25519Sig)) {
+		return fmt.Errorf("tuf: ed25519 self-test: unexpected signature")
+	}
+
+	for _, t := range []struct {
+		keyType, scheme string
+		value           interface{}
+		msg, sig        []byte
+	}{
+		{data.KeyTypeEd25519, data.KeySchemeEd25519, ed25519Verifier{PublicKey: data.HexBytes(edPub)}, nil, edSig},
+		{data.KeyTypeECDSA_SHA2_P256, data.KeySchemeECDSA_SHA2_P256, ecdsaVerifier{PublicKey: mustDecodeHex(selfTestEcdsaPoint)}, []byte("foo"), mustDecodeHex(selfTestEcdsaSig)},
+		{data.KeyTypeRSASSA_PSS_SHA256, data.KeySchemeRSASSA_PSS_SHA256, rsaPublic{PublicKey: selfTestRsaKey}, []byte("foo"), mustDecodeHex(selfTestRsaSig)},
+	} {
+		if err := selfTestVerify(t.keyType, t.scheme, t.value, t.msg, t.sig); err != nil {
+			return fmt.Errorf("tuf: %s self-test: %w", t.keyType, err)
+		}
+	}
+	return nil
+}
+
+func selfTestVerify(keyType, scheme string, value interface{}, msg, sig []byte) error {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	verifier, err := GetVerifier(&data.PublicKey{Type: keyType, Scheme: scheme, Value: valueBytes})
+	if err != nil {
+		return err
+	}
+	if err := verifier.Verify(msg, sig); err != nil {
+		return err
+	}
+	// A known answer test also has to fail when it should.
+	if verifier.Verify(append(append([]byte{}, msg...), 0), sig) == nil {
+		return fmt.Errorf("verification of a modified message succeeded")
+	}
+	return nil
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// ErrNotReady is returned by Ready with the failures of its checks.
+type ErrNotReady struct {
+	Errors []error
+}
+
+func (e ErrNotReady) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("tuf: not ready: %s", strings.Join(msgs, "; "))
+}
+
+// Ready checks that the built-in algorithms are registered, with
+// RequireAlgorithms, and that they work, with SelfTest. It is meant for
+// readiness probes of services relying on this package.
+func Ready() error {
+	var errs []error
+	if err := RequireAlgorithms(builtinAlgorithms...); err != nil {
+		errs = append(errs, err)
+	}
+	if err := SelfTest(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return ErrNotReady{errs}
+	}
+	return nil
+}
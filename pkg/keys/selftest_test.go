@@ -0,0 +1,26 @@
+package keys
+
+import (
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type SelfTestSuite struct{}
+
+var _ = Suite(&SelfTestSuite{})
+
+func (SelfTestSuite) TestReady(c *C) {
+	c.Assert(SelfTest(), IsNil)
+	c.Assert(Ready(), IsNil)
+}
+
+func (SelfTestSuite) TestNotReady(c *C) {
+	defer SnapshotRegistry()()
+	VerifierMap.Delete(data.KeyTypeRSASSA_PSS_SHA256)
+
+	err := Ready()
+	c.Assert(err, FitsTypeOf, ErrNotReady{})
+	c.Assert(err.(ErrNotReady).Errors, HasLen, 2)
+	c.Assert(err.(ErrNotReady).Errors[0], DeepEquals, ErrMissingAlgorithms{[]string{data.KeyTypeRSASSA_PSS_SHA256}})
+	c.Assert(err, ErrorMatches, "tuf: not ready: tuf: missing algorithms: rsa; tuf: rsa self-test: .*")
+}
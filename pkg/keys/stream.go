@@ -0,0 +1,47 @@
+package keys
+
+import (
+	"io"
+)
+
+// SignReader signs the message read from r until EOF with s.
+//
+// Signers that hash the message before signing it, such as ECDSA and RSA,
+// implement StreamSigner and hash the message as it is read. Other signers,
+// notably pure ed25519 which has to process the message twice, are given the
+// whole message: it is read into memory first, so the memory use grows with
+// the size of the message.
+func SignReader(s Signer, r io.Reader) ([]byte, error) {
+	if ss, ok := s.(StreamSigner); ok {
+		return ss.SignReader(r)
+	}
+	msg, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignMessage(msg)
+}
+
+// VerifyReader verifies that sig is a signature by v of the message read
+// from r until EOF. As with SignReader, the message is only streamed if v
+// implements StreamVerifier, and is read into memory otherwise.
+func VerifyReader(v Verifier, r io.Reader, sig []byte) error {
+	if sv, ok := v.(StreamVerifier); ok {
+		return sv.VerifyReader(r, sig)
+	}
+	msg, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return v.Verify(msg, sig)
+}
+
+// SignTo signs msg with s and writes the raw signature to w, returning the
+// number of bytes written.
+func SignTo(w io.Writer, s Signer, msg []byte) (int, error) {
+	sig, err := s.SignMessage(msg)
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(sig)
+}
@@ -0,0 +1,102 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type StreamSuite struct{}
+
+var _ = Suite(&StreamSuite{})
+
+func (StreamSuite) TestSignReader(c *C) {
+	msg := make([]byte, 4<<20)
+	_, err := rand.Read(msg)
+	c.Assert(err, IsNil)
+
+	for _, t := range []struct {
+		name          string
+		gen           func() (Signer, error)
+		deterministic bool
+	}{
+		{"ed25519", func() (Signer, error) { return GenerateEd25519Key() }, true},
+		{"ecdsa p256", func() (Signer, error) { return GenerateEcdsaKey() }, false},
+		{"ecdsa p384", func() (Signer, error) { return GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384) }, false},
+		{"ecdsa p521", func() (Signer, error) { return GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P521) }, false},
+		{"rsa", func() (Signer, error) { return GenerateRsaKey() }, false},
+	} {
+		comment := Commentf("signer = %s", t.name)
+		signer, err := t.gen()
+		c.Assert(err, IsNil, comment)
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil, comment)
+
+		streamed, err := SignReader(signer, bytes.NewReader(msg))
+		c.Assert(err, IsNil, comment)
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil, comment)
+		if t.deterministic {
+			c.Assert(streamed, DeepEquals, sig, comment)
+		}
+
+		// Streamed and non-streamed signatures are interchangeable.
+		c.Assert(verifier.Verify(msg, streamed), IsNil, comment)
+		c.Assert(VerifyReader(verifier, bytes.NewReader(msg), sig), IsNil, comment)
+		c.Assert(VerifyReader(verifier, bytes.NewReader(msg), streamed), IsNil, comment)
+		c.Assert(VerifyReader(verifier, bytes.NewReader(msg[1:]), streamed), NotNil, comment)
+	}
+}
+
+func (StreamSuite) TestNativeStreaming(c *C) {
+	ecdsaSigner, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsaSigner, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	edSigner, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+
+	var _ StreamSigner = ecdsaSigner
+	var _ StreamSigner = rsaSigner
+	_, ok := interface{}(edSigner).(StreamSigner)
+	c.Assert(ok, Equals, false)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read error")
+}
+
+func (StreamSuite) TestReadError(c *C) {
+	for _, gen := range []func() (Signer, error){
+		func() (Signer, error) { return GenerateEd25519Key() },
+		func() (Signer, error) { return GenerateEcdsaKey() },
+	} {
+		signer, err := gen()
+		c.Assert(err, IsNil)
+		_, err = SignReader(signer, errReader{})
+		c.Assert(err, ErrorMatches, "read error")
+
+		verifier, err := GetVerifier(signer.PublicData())
+		c.Assert(err, IsNil)
+		c.Assert(VerifyReader(verifier, errReader{}, nil), ErrorMatches, "read error")
+	}
+}
+
+func (StreamSuite) TestSignTo(c *C) {
+	signer, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	verifier, err := GetVerifier(signer.PublicData())
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	var buf bytes.Buffer
+	n, err := SignTo(&buf, signer, msg)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, buf.Len())
+	c.Assert(verifier.Verify(msg, buf.Bytes()), IsNil)
+}
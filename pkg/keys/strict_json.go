@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// checkDuplicateFields rejects JSON objects in raw with the same field more
+// than once, at any depth, with ErrMalformedKey. encoding/json keeps the
+// last occurrence of a field, while other parsers may keep the first, so
+// that a key object with duplicate fields could be read as different keys
+// by this package and by other TUF implementations.
+//
+// Field names are compared case-insensitively, as encoding/json matches them
+// to struct fields that way. Syntax errors are left to the decoding of raw.
+func checkDuplicateFields(raw []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	err := checkDuplicateFieldsValue(dec)
+	if _, ok := err.(duplicateFieldError); ok {
+		return fmt.Errorf("%w: %s", ErrMalformedKey, err)
+	}
+	return nil
+}
+
+type duplicateFieldError string
+
+func (e duplicateFieldError) Error() string {
+	return fmt.Sprintf("duplicate field %q in key object", string(e))
+}
+
+func checkDuplicateFieldsValue(dec *json.Decoder) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch t {
+	case json.Delim('{'):
+		seen := make(map[string]struct{})
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := t.(string)
+			folded := strings.ToLower(strings.ToUpper(name))
+			if _, ok := seen[folded]; ok {
+				return duplicateFieldError(name)
+			}
+			seen[folded] = struct{}{}
+			if err := checkDuplicateFieldsValue(dec); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for dec.More() {
+			if err := checkDuplicateFieldsValue(dec); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
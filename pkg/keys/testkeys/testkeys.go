@@ -0,0 +1,89 @@
+// Package testkeys provides deterministic keys for use in tests and
+// fixtures. The keys are derived from a seed string, so a given seed always
+// yields the same key and key IDs. They must never be used outside of tests.
+package testkeys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+// DefaultSeed is the seed used to generate the well-known test keys whose
+// key IDs are exposed below.
+const DefaultSeed = "go-tuf-test-key-seed-0123456789a"
+
+const (
+	// Ed25519KeyID is the key ID of Ed25519(DefaultSeed).
+	Ed25519KeyID = "6f9c71f9a252b6ed22be48f5b2025f807201157f20386d9fe73bbd4af5e54bfb"
+	// ECDSAKeyID is the key ID of ECDSA(DefaultSeed).
+	ECDSAKeyID = "d12fe53bb2f2e9c63e131d02b65fc5280388281da0ba98226a7957ab12de4a84"
+)
+
+type privateKeyValue struct {
+	Public  data.HexBytes `json:"public"`
+	Private data.HexBytes `json:"private"`
+}
+
+// Ed25519 returns the ed25519 private key derived from seed.
+func Ed25519(seed string) *data.PrivateKey {
+	digest := sha256.Sum256([]byte("ed25519:" + seed))
+	priv := ed25519.NewKeyFromSeed(digest[:])
+	return mustSigner(data.KeyTypeEd25519, data.KeySchemeEd25519, privateKeyValue{
+		Public:  data.HexBytes(priv.Public().(ed25519.PublicKey)),
+		Private: data.HexBytes(priv),
+	})
+}
+
+// ECDSA returns the NIST P-256 ECDSA private key derived from seed.
+func ECDSA(seed string) *data.PrivateKey {
+	curve := elliptic.P256()
+	digest := sha256.Sum256([]byte("ecdsa-sha2-nistp256:" + seed))
+
+	// Map the digest onto a scalar in [1, N-1].
+	n := new(big.Int).Sub(curve.Params().N, big.NewInt(1))
+	d := new(big.Int).SetBytes(digest[:])
+	d.Mod(d, n)
+	d.Add(d, big.NewInt(1))
+
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.Curve = curve
+	priv.X, priv.Y = curve.ScalarBaseMult(d.FillBytes(make([]byte, 32)))
+	return mustSigner(data.KeyTypeECDSA_SHA2_P256, data.KeySchemeECDSA_SHA2_P256, privateKeyValue{
+		Public:  elliptic.Marshal(curve, priv.X, priv.Y),
+		Private: d.FillBytes(make([]byte, 32)),
+	})
+}
+
+// Public returns the public part of a private key returned by this package.
+func Public(key *data.PrivateKey) *data.PublicKey {
+	signer, err := keys.GetSigner(key)
+	if err != nil {
+		panic(err)
+	}
+	return signer.PublicData()
+}
+
+func mustSigner(keyType, scheme string, value privateKeyValue) *data.PrivateKey {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	key := &data.PrivateKey{
+		Type:       keyType,
+		Scheme:     scheme,
+		Algorithms: data.HashAlgorithms,
+		Value:      valueBytes,
+	}
+	// Make sure the key is usable before handing it out.
+	if _, err := keys.GetSigner(key); err != nil {
+		panic(err)
+	}
+	return key
+}
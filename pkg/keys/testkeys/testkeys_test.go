@@ -0,0 +1,41 @@
+package testkeys
+
+import (
+	"testing"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type TestKeysSuite struct{}
+
+var _ = Suite(&TestKeysSuite{})
+
+func (TestKeysSuite) TestStableKeyIDs(c *C) {
+	c.Assert(Public(Ed25519(DefaultSeed)).IDs()[0], Equals, Ed25519KeyID)
+	c.Assert(Public(ECDSA(DefaultSeed)).IDs()[0], Equals, ECDSAKeyID)
+}
+
+func (TestKeysSuite) TestDeterministic(c *C) {
+	c.Assert(Ed25519("foo"), DeepEquals, Ed25519("foo"))
+	c.Assert(ECDSA("foo"), DeepEquals, ECDSA("foo"))
+	c.Assert(Public(Ed25519("foo")).IDs(), Not(DeepEquals), Public(Ed25519("bar")).IDs())
+	c.Assert(Public(ECDSA("foo")).IDs(), Not(DeepEquals), Public(ECDSA("bar")).IDs())
+}
+
+func (TestKeysSuite) TestSignVerify(c *C) {
+	for _, priv := range []*data.PrivateKey{Ed25519(DefaultSeed), ECDSA(DefaultSeed)} {
+		signer, err := keys.GetSigner(priv)
+		c.Assert(err, IsNil)
+		msg := []byte("foo")
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+		verifier, err := keys.GetVerifier(Public(priv))
+		c.Assert(err, IsNil)
+		c.Assert(verifier.Verify(msg, sig), IsNil)
+	}
+}
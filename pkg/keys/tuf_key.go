@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// FromTUFKey parses a key object as found in the "keys" map of TUF root or
+// delegations metadata, and returns the public key along with its primary
+// key ID. Unknown fields in the key object are ignored, but fields appearing
+// more than once fail with ErrMalformedKey.
+func FromTUFKey(raw json.RawMessage) (*data.PublicKey, string, error) {
+	if err := checkDuplicateFields(raw); err != nil {
+		return nil, "", err
+	}
+	key := &data.PublicKey{}
+	if err := json.Unmarshal(raw, key); err != nil {
+		return nil, "", err
+	}
+	if key.Type == "" {
+		return nil, "", errors.New("tuf: key is missing keytype")
+	}
+	if len(key.Value) == 0 {
+		return nil, "", errors.New("tuf: key is missing keyval")
+	}
+	return key, key.IDs()[0], nil
+}
+
+// MarshalKeyStable encodes pk as a key object with its fields in a fixed
+// order, the lexicographic one securesystemslib writes, such as
+// keyid_hash_algorithms, keytype, keyval and scheme, so that key files
+// written to disk diff cleanly. The fields of the key value are sorted the
+// same way. Unlike the canonical form key IDs are computed over, the output
+// is plain JSON, with control characters such as the newlines of PEM keys
+// escaped; the key IDs of pk are unchanged.
+func MarshalKeyStable(pk *data.PublicKey) ([]byte, error) {
+	b, err := json.Marshal(pk)
+	if err != nil {
+		return nil, err
+	}
+	// Encoding generic objects sorts their fields, recursively.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
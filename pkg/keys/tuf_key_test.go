@@ -0,0 +1,78 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/theupdateframework/go-tuf/data"
+	. "gopkg.in/check.v1"
+)
+
+type TUFKeySuite struct{}
+
+var _ = Suite(&TUFKeySuite{})
+
+// rootKeyEntry is a key entry taken from a root.json generated by python-tuf.
+const rootKeyEntry = `{"keyid_hash_algorithms": ["sha256", "sha512"], "keytype": "ed25519", "keyval": {"public": "ba9491721b6b709a0a0cb02760e7cc84745e46cd905675e1686b5f362ec8df0f"}, "scheme": "ed25519"}`
+
+const rootKeyID = "79cc8a40a8e6658daae229b1524402956637ccced210981e5ea719a0de0f8752"
+
+func (TUFKeySuite) TestFromTUFKey(c *C) {
+	key, id, err := FromTUFKey(json.RawMessage(rootKeyEntry))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, rootKeyID)
+	c.Assert(key.Type, Equals, data.KeyTypeEd25519)
+	c.Assert(key.Scheme, Equals, data.KeySchemeEd25519)
+	c.Assert(key.Algorithms, DeepEquals, data.HashAlgorithms)
+	_, err = GetVerifier(key)
+	c.Assert(err, IsNil)
+}
+
+func (TUFKeySuite) TestFromTUFKeyUnknownFields(c *C) {
+	entry := `{"keyid_hash_algorithms": ["sha256", "sha512"], "keytype": "ed25519", "keyval": {"public": "ba9491721b6b709a0a0cb02760e7cc84745e46cd905675e1686b5f362ec8df0f"}, "scheme": "ed25519", "x-custom": {"foo": "bar"}}`
+	_, id, err := FromTUFKey(json.RawMessage(entry))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, rootKeyID)
+}
+
+func (TUFKeySuite) TestFromTUFKeyInvalid(c *C) {
+	_, _, err := FromTUFKey(json.RawMessage(`[]`))
+	c.Assert(err, NotNil)
+	_, _, err = FromTUFKey(json.RawMessage(`{"keyval": {"public": "00"}}`))
+	c.Assert(err, ErrorMatches, "tuf: key is missing keytype")
+	_, _, err = FromTUFKey(json.RawMessage(`{"keytype": "ed25519"}`))
+	c.Assert(err, ErrorMatches, "tuf: key is missing keyval")
+}
+
+func (TUFKeySuite) TestMarshalKeyStable(c *C) {
+	// The fields are written in securesystemslib order, whatever the order
+	// of the parsed key object.
+	key, _, err := FromTUFKey(json.RawMessage(`{"scheme": "ed25519", "keyval": {"public": "ba9491721b6b709a0a0cb02760e7cc84745e46cd905675e1686b5f362ec8df0f"}, "keytype": "ed25519", "keyid_hash_algorithms": ["sha256", "sha512"]}`))
+	c.Assert(err, IsNil)
+	b, err := MarshalKeyStable(key)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"keyid_hash_algorithms":["sha256","sha512"],"keytype":"ed25519","keyval":{"public":"ba9491721b6b709a0a0cb02760e7cc84745e46cd905675e1686b5f362ec8df0f"},"scheme":"ed25519"}`)
+
+	parsed, id, err := FromTUFKey(b)
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, rootKeyID)
+	c.Assert(parsed.IDs(), DeepEquals, key.IDs())
+
+	// The output of every key type is byte-stable.
+	ec, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsa, err := GenerateRsaKey()
+	c.Assert(err, IsNil)
+	for _, s := range []Signer{ec, rsa} {
+		first, err := MarshalKeyStable(s.PublicData())
+		c.Assert(err, IsNil)
+		for i := 0; i < 10; i++ {
+			b, err := MarshalKeyStable(s.PublicData())
+			c.Assert(err, IsNil)
+			c.Assert(bytes.Equal(b, first), Equals, true)
+		}
+		parsed, _, err := FromTUFKey(first)
+		c.Assert(err, IsNil)
+		c.Assert(parsed.IDs(), DeepEquals, s.PublicData().IDs())
+	}
+}
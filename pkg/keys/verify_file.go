@@ -0,0 +1,72 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// VerifyFile verifies the detached signature stored at sigPath against the
+// contents of the file at targetPath using the public key pub.
+//
+// The signature file may contain either a hex or a base64 encoded signature.
+// If the verifier for pub supports it, the target is streamed through the
+// key's hash function rather than read into memory.
+func VerifyFile(targetPath, sigPath string, pub *data.PublicKey) error {
+	verifier, err := GetVerifier(pub)
+	if err != nil {
+		return err
+	}
+
+	sigFile, err := ioutil.ReadFile(sigPath)
+	if err != nil {
+		return err
+	}
+	sig, err := decodeSignature(sigFile)
+	if err != nil {
+		return fmt.Errorf("tuf: error decoding signature %s: %w", sigPath, err)
+	}
+
+	f, err := os.Open(targetPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if sv, ok := verifier.(StreamVerifier); ok {
+		err = sv.VerifyReader(f, sig)
+	} else {
+		var msg []byte
+		msg, err = ioutil.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		err = verifier.Verify(msg, sig)
+	}
+	if err != nil {
+		return fmt.Errorf("tuf: signature %s does not match %s: %w", sigPath, targetPath, err)
+	}
+	return nil
+}
+
+// decodeSignature decodes a hex or base64 encoded signature, ignoring any
+// surrounding whitespace.
+func decodeSignature(b []byte) ([]byte, error) {
+	s := string(bytes.TrimSpace(b))
+	if s == "" {
+		return nil, errors.New("empty signature")
+	}
+	if sig, err := hex.DecodeString(s); err == nil {
+		return sig, nil
+	}
+	if sig, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return sig, nil
+	}
+	return nil, errors.New("signature is neither hex nor base64 encoded")
+}
@@ -0,0 +1,54 @@
+package keys
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type VerifyFileSuite struct{}
+
+var _ = Suite(&VerifyFileSuite{})
+
+func (VerifyFileSuite) TestVerifyFile(c *C) {
+	ed25519Signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ecdsaSigner, err := GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+
+	for _, signer := range []Signer{ed25519Signer, ecdsaSigner} {
+		dir := c.MkDir()
+		target := filepath.Join(dir, "target")
+		msg := []byte("target contents")
+		c.Assert(ioutil.WriteFile(target, msg, 0644), IsNil)
+
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+
+		hexSig := filepath.Join(dir, "target.sig.hex")
+		c.Assert(ioutil.WriteFile(hexSig, []byte(hex.EncodeToString(sig)+"\n"), 0644), IsNil)
+		c.Assert(VerifyFile(target, hexSig, signer.PublicData()), IsNil)
+
+		b64Sig := filepath.Join(dir, "target.sig.b64")
+		c.Assert(ioutil.WriteFile(b64Sig, []byte(base64.StdEncoding.EncodeToString(sig)), 0644), IsNil)
+		c.Assert(VerifyFile(target, b64Sig, signer.PublicData()), IsNil)
+
+		// Tampering with the target must fail verification.
+		c.Assert(ioutil.WriteFile(target, []byte("tampered contents"), 0644), IsNil)
+		c.Assert(VerifyFile(target, hexSig, signer.PublicData()), ErrorMatches, "tuf: signature .* does not match .*")
+	}
+}
+
+func (VerifyFileSuite) TestVerifyFileBadSignatureEncoding(c *C) {
+	signer, err := GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	dir := c.MkDir()
+	target := filepath.Join(dir, "target")
+	c.Assert(ioutil.WriteFile(target, []byte("foo"), 0644), IsNil)
+	sig := filepath.Join(dir, "target.sig")
+	c.Assert(ioutil.WriteFile(sig, []byte("not a signature!"), 0644), IsNil)
+	c.Assert(VerifyFile(target, sig, signer.PublicData()), ErrorMatches, "tuf: error decoding signature .*")
+}
@@ -0,0 +1,99 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+
+	"github.com/theupdateframework/go-tuf/internal/zeroize"
+	"golang.org/x/crypto/curve25519"
+)
+
+var (
+	// curve25519P is the field prime 2^255 - 19.
+	curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	// edwards25519D is the constant d = -121665/121666 of the twisted
+	// Edwards curve.
+	edwards25519D = new(big.Int).Mod(new(big.Int).Mul(
+		big.NewInt(-121665),
+		new(big.Int).ModInverse(big.NewInt(121666), curve25519P),
+	), curve25519P)
+)
+
+// Ed25519ToX25519Public returns the X25519 public key corresponding to the
+// ed25519 public key pub, using the birational map u = (1 + y) / (1 - y)
+// from the Edwards y coordinate to the Montgomery u coordinate, as libsodium
+// does. It rejects non-canonical encodings, encodings of no point, and
+// points of small order, for which any shared secret is predictable.
+func Ed25519ToX25519Public(pub ed25519.PublicKey) ([]byte, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: ed25519 public key must be %d bytes, got %d", ErrInvalidKey, ed25519.PublicKeySize, len(pub))
+	}
+	enc := make([]byte, len(pub))
+	copy(enc, pub)
+	xSign := enc[31] >> 7
+	enc[31] &= 0x7f
+	y := new(big.Int).SetBytes(reverseBytes(enc))
+	if y.Cmp(curve25519P) >= 0 {
+		return nil, fmt.Errorf("%w: non-canonical ed25519 public key", ErrInvalidKey)
+	}
+
+	// The point exists if x^2 = (y^2 - 1) / (d*y^2 + 1) is a square.
+	y2 := new(big.Int).Mul(y, y)
+	num := new(big.Int).Sub(y2, big.NewInt(1))
+	den := new(big.Int).Add(new(big.Int).Mul(edwards25519D, y2), big.NewInt(1))
+	x2 := num.Mul(num, den.ModInverse(den.Mod(den, curve25519P), curve25519P))
+	x2.Mod(x2, curve25519P)
+	if new(big.Int).ModSqrt(x2, curve25519P) == nil {
+		return nil, fmt.Errorf("%w: ed25519 public key is not a point on the curve", ErrInvalidKey)
+	}
+	if x2.Sign() == 0 && xSign == 1 {
+		return nil, fmt.Errorf("%w: non-canonical ed25519 public key", ErrInvalidKey)
+	}
+
+	oneMinusY := new(big.Int).Sub(big.NewInt(1), y)
+	oneMinusY.Mod(oneMinusY, curve25519P)
+	if oneMinusY.Sign() == 0 {
+		return nil, fmt.Errorf("%w: ed25519 public key has small order", ErrInvalidKey)
+	}
+	u := new(big.Int).Add(big.NewInt(1), y)
+	u.Mul(u, oneMinusY.ModInverse(oneMinusY, curve25519P))
+	u.Mod(u, curve25519P)
+	out := reverseBytes(u.FillBytes(make([]byte, curve25519.PointSize)))
+
+	// Clamped scalars are multiples of the cofactor, so the product with a
+	// point of small order, and only with one, is the point at infinity,
+	// for which X25519 returns an error.
+	if _, err := curve25519.X25519(out, out); err != nil {
+		return nil, fmt.Errorf("%w: ed25519 public key has small order", ErrInvalidKey)
+	}
+	return out, nil
+}
+
+// Ed25519ToX25519Private returns the X25519 private key corresponding to the
+// ed25519 private key priv: the clamped first half of the SHA-512 digest of
+// its seed, which is the scalar ed25519 signs with.
+func Ed25519ToX25519Private(priv ed25519.PrivateKey) ([]byte, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: ed25519 private key must be %d bytes, got %d", ErrInvalidKey, ed25519.PrivateKeySize, len(priv))
+	}
+	h := sha512.Sum512(priv.Seed())
+	defer zeroize.Bytes(h[:])
+	out := make([]byte, curve25519.ScalarSize)
+	copy(out, h[:curve25519.ScalarSize])
+	out[0] &= 248
+	out[31] &= 127
+	out[31] |= 64
+	return out, nil
+}
+
+// reverseBytes reverses b in place, converting between the little-endian
+// encoding of curve25519 field elements and the big-endian one of big.Int,
+// and returns it.
+func reverseBytes(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
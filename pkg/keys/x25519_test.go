@@ -0,0 +1,75 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+
+	"golang.org/x/crypto/curve25519"
+	. "gopkg.in/check.v1"
+)
+
+type X25519Suite struct{}
+
+var _ = Suite(&X25519Suite{})
+
+func (X25519Suite) TestKnownVector(c *C) {
+	// RFC 8032 test 1 key. The X25519 public key was computed by OpenSSL
+	// from the converted private key.
+	seed := mustHex(c, "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	priv := ed25519.NewKeyFromSeed(seed)
+	c.Assert(hex.EncodeToString(priv.Public().(ed25519.PublicKey)), Equals, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+
+	xpriv, err := Ed25519ToX25519Private(priv)
+	c.Assert(err, IsNil)
+	c.Assert(hex.EncodeToString(xpriv), Equals, "307c83864f2833cb427a2ef1c00a013cfdff2768d980c0a3a520f006904de94f")
+
+	xpub, err := Ed25519ToX25519Public(priv.Public().(ed25519.PublicKey))
+	c.Assert(err, IsNil)
+	c.Assert(hex.EncodeToString(xpub), Equals, "d85e07ec22b0ad881537c2f44d662d1a143cf830c57aca4305d85c7a90f6b62e")
+}
+
+func (X25519Suite) TestConsistency(c *C) {
+	for i := 0; i < 20; i++ {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		c.Assert(err, IsNil)
+		xpriv, err := Ed25519ToX25519Private(priv)
+		c.Assert(err, IsNil)
+		xpub, err := Ed25519ToX25519Public(pub)
+		c.Assert(err, IsNil)
+		expected, err := curve25519.X25519(xpriv, curve25519.Basepoint)
+		c.Assert(err, IsNil)
+		c.Assert(xpub, DeepEquals, expected)
+	}
+}
+
+func (X25519Suite) TestRejectInvalidPublicKeys(c *C) {
+	for _, tc := range []struct {
+		name string
+		pub  string
+	}{
+		{"identity", "0100000000000000000000000000000000000000000000000000000000000000"},
+		{"order 2", "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{"order 4", "0000000000000000000000000000000000000000000000000000000000000000"},
+		{"order 8", "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"},
+		{"order 8", "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05"},
+		{"y = p", "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{"negative zero x", "0100000000000000000000000000000000000000000000000000000000000080"},
+		{"not on curve", "0200000000000000000000000000000000000000000000000000000000000000"},
+		{"short", "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f70751"},
+	} {
+		_, err := Ed25519ToX25519Public(mustHex(c, tc.pub))
+		c.Assert(errors.Is(err, ErrInvalidKey), Equals, true, Commentf("%s: %v", tc.name, err))
+	}
+}
+
+func (X25519Suite) TestRejectInvalidPrivateKey(c *C) {
+	_, err := Ed25519ToX25519Private(make([]byte, ed25519.SeedSize))
+	c.Assert(errors.Is(err, ErrInvalidKey), Equals, true)
+}
+
+func mustHex(c *C, s string) []byte {
+	b, err := hex.DecodeString(s)
+	c.Assert(err, IsNil)
+	return b
+}
@@ -0,0 +1,43 @@
+package sign
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+// An EnvelopeSigner is a private key used by SignEnvelope.
+type EnvelopeSigner struct {
+	Key *data.PrivateKey
+}
+
+// SignEnvelope signs the canonical form of signed with each of signers, and
+// returns the JSON encoding of the resulting {"signed", "signatures"}
+// envelope, the layout used by TUF metadata. The envelope holds signed in
+// canonical form, so that it is exactly the message that was signed.
+func SignEnvelope(signed json.RawMessage, signers []EnvelopeSigner) ([]byte, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("tuf: no signers")
+	}
+	b, err := cjson.EncodeCanonical(signed)
+	if err != nil {
+		return nil, err
+	}
+	s := &data.Signed{Signed: b}
+	for _, es := range signers {
+		if es.Key == nil {
+			return nil, errors.New("tuf: envelope signer has no key")
+		}
+		k, err := keys.GetSigner(es.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err := Sign(s, k); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(s)
+}
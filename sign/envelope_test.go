@@ -0,0 +1,53 @@
+package sign
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+func TestSignEnvelope(t *testing.T) {
+	var signers []EnvelopeSigner
+	verifiers := make(map[string]keys.Verifier)
+	for _, gen := range []func() (keys.Signer, error){
+		func() (keys.Signer, error) { return keys.GenerateEd25519Key() },
+		func() (keys.Signer, error) { return keys.GenerateEcdsaKey() },
+	} {
+		k, err := gen()
+		assert.NoError(t, err)
+		pk, err := k.MarshalPrivateKey()
+		assert.NoError(t, err)
+		signers = append(signers, EnvelopeSigner{Key: pk})
+		v, err := keys.GetVerifier(k.PublicData())
+		assert.NoError(t, err)
+		verifiers[k.PublicData().IDs()[0]] = v
+	}
+
+	signed := json.RawMessage(`{"version": 1, "_type": "test", "name": "foo"}`)
+	envelope, err := SignEnvelope(signed, signers)
+	assert.NoError(t, err)
+
+	s := &data.Signed{}
+	assert.NoError(t, json.Unmarshal(envelope, s))
+	canonical, err := cjson.EncodeCanonical(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, string(canonical), string(s.Signed))
+
+	// Each signature verifies on its own.
+	assert.Len(t, s.Signatures, 2)
+	for _, sig := range s.Signatures {
+		v, ok := verifiers[sig.KeyID]
+		assert.True(t, ok)
+		assert.NoError(t, v.Verify(s.Signed, sig.Signature))
+		assert.Error(t, v.Verify([]byte(`{"_type":"test"}`), sig.Signature))
+	}
+
+	_, err = SignEnvelope(signed, nil)
+	assert.Error(t, err)
+	_, err = SignEnvelope(json.RawMessage(`{"version": 1.5}`), signers)
+	assert.Error(t, err)
+}
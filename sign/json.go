@@ -0,0 +1,26 @@
+package sign
+
+import (
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+// SignJSON signs the canonical JSON encoding of v with s, the form Marshal
+// signs, and returns the raw signature.
+func SignJSON(s keys.Signer, v interface{}) ([]byte, error) {
+	b, err := cjson.EncodeCanonical(v)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignMessage(b)
+}
+
+// VerifyJSON verifies that sig is a signature by verifier of the canonical
+// JSON encoding of v, as produced by SignJSON.
+func VerifyJSON(verifier keys.Verifier, v interface{}, sig []byte) error {
+	b, err := cjson.EncodeCanonical(v)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(b, sig)
+}
@@ -0,0 +1,42 @@
+package sign
+
+import (
+	"testing"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+func TestSignJSON(t *testing.T) {
+	type meta struct {
+		Type    string            `json:"_type"`
+		Version int               `json:"version"`
+		Names   map[string]string `json:"names"`
+	}
+	v := meta{Type: "test", Version: 1, Names: map[string]string{"b": "2", "a": "1"}}
+
+	k, err := keys.GenerateEd25519Key()
+	require.NoError(t, err)
+	verifier, err := keys.GetVerifier(k.PublicData())
+	require.NoError(t, err)
+
+	sig, err := SignJSON(k, v)
+	require.NoError(t, err)
+
+	// The signature is over the independently canonicalized bytes.
+	canonical, err := cjson.EncodeCanonical(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"_type":"test","names":{"a":"1","b":"2"},"version":1}`, string(canonical))
+	assert.NoError(t, verifier.Verify(canonical, sig))
+
+	assert.NoError(t, VerifyJSON(verifier, v, sig))
+	assert.NoError(t, VerifyJSON(verifier, map[string]interface{}{"version": 1, "names": map[string]string{"a": "1", "b": "2"}, "_type": "test"}, sig))
+
+	v.Version = 2
+	assert.Error(t, VerifyJSON(verifier, v, sig))
+
+	_, err = SignJSON(k, func() {})
+	assert.Error(t, err)
+}
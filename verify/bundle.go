@@ -0,0 +1,53 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+// A Bundle is a signature shipped along with the public key that made it,
+// as in sigstore style attestation bundles.
+type Bundle struct {
+	// Algorithm is the signature scheme, which must match the scheme of
+	// the embedded key.
+	Algorithm string
+	// PublicKey is the embedded key, as a TUF key object.
+	PublicKey []byte
+	// Signature is the signature of the message.
+	Signature []byte
+}
+
+// VerifyBundle verifies that the bundle signature is a valid signature of
+// msg by the embedded public key, and returns the ID of that key.
+//
+// A valid bundle only proves that the message was signed by whoever holds
+// the embedded key: callers must check the returned key ID against their
+// trust policy before trusting the message.
+func VerifyBundle(msg []byte, bundle *Bundle) (string, error) {
+	pk, keyID, err := keys.FromTUFKey(bundle.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	if bundle.Algorithm == "" || bundle.Algorithm != keyScheme(pk) {
+		return "", ErrWrongMethod
+	}
+	verifier, err := keys.GetVerifier(pk)
+	if err != nil {
+		return "", ErrInvalidKey
+	}
+	if err := verifier.Verify(msg, bundle.Signature); err != nil {
+		return "", ErrInvalid
+	}
+	return keyID, nil
+}
+
+// keyScheme returns the signature scheme of pk, falling back on its type
+// for keys without a scheme.
+func keyScheme(pk *data.PublicKey) string {
+	if pk.Scheme != "" {
+		return pk.Scheme
+	}
+	return pk.Type
+}
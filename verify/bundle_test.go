@@ -0,0 +1,49 @@
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+func TestVerifyBundle(t *testing.T) {
+	signer, err := keys.GenerateEd25519Key()
+	assert.NoError(t, err)
+	other, err := keys.GenerateEd25519Key()
+	assert.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := signer.SignMessage(msg)
+	assert.NoError(t, err)
+	pub, err := json.Marshal(signer.PublicData())
+	assert.NoError(t, err)
+	otherPub, err := json.Marshal(other.PublicData())
+	assert.NoError(t, err)
+
+	// Matching embedded key.
+	keyID, err := VerifyBundle(msg, &Bundle{Algorithm: data.KeySchemeEd25519, PublicKey: pub, Signature: sig})
+	assert.NoError(t, err)
+	assert.Equal(t, signer.PublicData().IDs()[0], keyID)
+
+	_, err = VerifyBundle([]byte("bar"), &Bundle{Algorithm: data.KeySchemeEd25519, PublicKey: pub, Signature: sig})
+	assert.Equal(t, ErrInvalid, err)
+
+	// Tampered embedded key.
+	keyID, err = VerifyBundle(msg, &Bundle{Algorithm: data.KeySchemeEd25519, PublicKey: otherPub, Signature: sig})
+	assert.Equal(t, ErrInvalid, err)
+	assert.Empty(t, keyID)
+
+	// The algorithm must match the embedded key.
+	_, err = VerifyBundle(msg, &Bundle{Algorithm: data.KeySchemeECDSA_SHA2_P256, PublicKey: pub, Signature: sig})
+	assert.Equal(t, ErrWrongMethod, err)
+	_, err = VerifyBundle(msg, &Bundle{PublicKey: pub, Signature: sig})
+	assert.Equal(t, ErrWrongMethod, err)
+
+	// Malformed embedded key.
+	_, err = VerifyBundle(msg, &Bundle{Algorithm: data.KeySchemeEd25519, PublicKey: []byte("{}"), Signature: sig})
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+}
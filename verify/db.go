@@ -1,6 +1,8 @@
 package verify
 
 import (
+	"fmt"
+
 	"github.com/theupdateframework/go-tuf/data"
 	"github.com/theupdateframework/go-tuf/internal/roles"
 	"github.com/theupdateframework/go-tuf/pkg/keys"
@@ -17,23 +19,48 @@ func (r *Role) ValidKey(id string) bool {
 }
 
 type DB struct {
-	roles     map[string]*Role
-	verifiers map[string]keys.Verifier
+	roles         map[string]*Role
+	verifiers     map[string]keys.Verifier
+	maxSignatures int
 }
 
-func NewDB() *DB {
-	return &DB{
-		roles:     make(map[string]*Role),
-		verifiers: make(map[string]keys.Verifier),
+// A DBOption configures a DB.
+type DBOption func(*DB)
+
+// WithMaxSignatures bounds the number of signatures the DB accepts in
+// VerifySignatures, VerifyEnvelope and the other functions verifying lists
+// of signatures, so that metadata with a huge number of bogus signatures is
+// rejected with ErrTooManySignatures before any of them is verified. The
+// default is DefaultMaxSignatures, and a value of 0 or less disables the
+// limit.
+func WithMaxSignatures(n int) DBOption {
+	return func(db *DB) {
+		db.maxSignatures = n
+	}
+}
+
+func NewDB(opts ...DBOption) *DB {
+	db := &DB{
+		roles:         make(map[string]*Role),
+		verifiers:     make(map[string]keys.Verifier),
+		maxSignatures: DefaultMaxSignatures,
+	}
+	for _, opt := range opts {
+		opt(db)
 	}
+	return db
 }
 
 // NewDBFromDelegations returns a DB that verifies delegations
 // of a given Targets.
-func NewDBFromDelegations(d *data.Delegations) (*DB, error) {
+func NewDBFromDelegations(d *data.Delegations, opts ...DBOption) (*DB, error) {
 	db := &DB{
-		roles:     make(map[string]*Role, len(d.Roles)),
-		verifiers: make(map[string]keys.Verifier, len(d.Keys)),
+		roles:         make(map[string]*Role, len(d.Roles)),
+		verifiers:     make(map[string]keys.Verifier, len(d.Keys)),
+		maxSignatures: DefaultMaxSignatures,
+	}
+	for _, opt := range opts {
+		opt(db)
 	}
 	for _, r := range d.Roles {
 		if _, ok := roles.TopLevelRoles[r.Name]; ok {
@@ -99,6 +126,19 @@ func (db *DB) GetVerifier(id string) (keys.Verifier, error) {
 	return k, nil
 }
 
+// VerifySignature verifies that sig is a valid signature of msg by the key
+// with the signature's key ID in db.
+func (db *DB) VerifySignature(msg []byte, sig data.Signature) error {
+	verifier, err := db.GetVerifier(sig.KeyID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, sig.KeyID)
+	}
+	if err := verifier.Verify(msg, sig.Signature); err != nil {
+		return ErrInvalid
+	}
+	return nil
+}
+
 func (db *DB) GetRole(name string) *Role {
 	return db.roles[name]
 }
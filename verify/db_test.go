@@ -1,10 +1,14 @@
 package verify
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
 )
 
 func TestDelegationsDB(t *testing.T) {
@@ -55,3 +59,34 @@ func TestDelegationsDB(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySignature(t *testing.T) {
+	signer, err := keys.GenerateEd25519Key()
+	assert.NoError(t, err)
+	pub := signer.PublicData()
+	id := pub.IDs()[0]
+	db := NewDB()
+	assert.NoError(t, db.AddKey(id, pub))
+
+	msg := []byte("foo")
+	sigBytes, err := signer.SignMessage(msg)
+	assert.NoError(t, err)
+
+	// A valid signature.
+	assert.NoError(t, db.VerifySignature(msg, data.Signature{KeyID: id, Signature: sigBytes}))
+
+	// A signature over another message.
+	assert.Equal(t, ErrInvalid, db.VerifySignature([]byte("bar"), data.Signature{KeyID: id, Signature: sigBytes}))
+
+	// An unknown key ID.
+	err = db.VerifySignature(msg, data.Signature{KeyID: "unknown", Signature: sigBytes})
+	assert.True(t, errors.Is(err, ErrMissingKey))
+	assert.Contains(t, err.Error(), "unknown")
+
+	// Signatures are hex decoded when parsing metadata, so bad hex never
+	// reaches the DB.
+	var sig data.Signature
+	assert.Error(t, json.Unmarshal([]byte(`{"keyid":"`+id+`","sig":"not hex"}`), &sig))
+	assert.NoError(t, json.Unmarshal([]byte(`{"keyid":"`+id+`","sig":"`+hex.EncodeToString(sigBytes)+`"}`), &sig))
+	assert.NoError(t, db.VerifySignature(msg, sig))
+}
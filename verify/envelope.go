@@ -0,0 +1,73 @@
+package verify
+
+import (
+	"encoding/json"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// VerifyEnvelope verifies a {"signed", "signatures"} envelope, as produced
+// by sign.SignEnvelope, against the keys in db. The signatures must be over
+// the canonical form of signed, and at least threshold distinct keys must
+// have signed it: a key added to db under several key objects, such as with
+// different keyid_hash_algorithms, only counts once. Signatures by keys that are not in db are ignored, but an
+// invalid signature by a known key fails the verification, and so do
+// several signatures with the same key ID and more signatures than the limit
+// of db.
+func (db *DB) VerifyEnvelope(envelope []byte, threshold int) error {
+	if threshold < 1 {
+		return ErrInvalidThreshold
+	}
+	s := &data.Signed{}
+	if err := json.Unmarshal(envelope, s); err != nil {
+		return err
+	}
+	if err := db.checkSignatureCount(len(s.Signatures)); err != nil {
+		return err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(s.Signed, &decoded); err != nil {
+		return err
+	}
+	msg, err := cjson.EncodeCanonical(decoded)
+	if err != nil {
+		return err
+	}
+
+	keyIDs := make(map[string]struct{}, len(s.Signatures))
+	for _, sig := range s.Signatures {
+		if _, ok := keyIDs[sig.KeyID]; ok {
+			return ErrDuplicateKeyID
+		}
+		keyIDs[sig.KeyID] = struct{}{}
+	}
+
+	// Keys with several IDs, or under several key objects, only count
+	// once.
+	seen := make(map[string]struct{})
+	valid := 0
+	for _, sig := range s.Signatures {
+		verifier, err := db.GetVerifier(sig.KeyID)
+		if err != nil {
+			continue
+		}
+		if err := verifier.Verify(msg, sig.Signature); err != nil {
+			return ErrInvalid
+		}
+		_, seenID := seen[sig.KeyID]
+		_, seenKey := seen[verifier.Public()]
+		if !seenID && !seenKey {
+			for _, id := range verifier.MarshalPublicKey().IDs() {
+				seen[id] = struct{}{}
+			}
+			seen[verifier.Public()] = struct{}{}
+			valid++
+		}
+	}
+	if valid < threshold {
+		return ErrRoleThreshold{threshold, valid}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+	"github.com/theupdateframework/go-tuf/sign"
+)
+
+func TestVerifyEnvelope(t *testing.T) {
+	db := NewDB()
+	var signers []sign.EnvelopeSigner
+	var keySigners []keys.Signer
+	for i := 0; i < 2; i++ {
+		k, err := keys.GenerateEd25519Key()
+		assert.NoError(t, err)
+		pk, err := k.MarshalPrivateKey()
+		assert.NoError(t, err)
+		signers = append(signers, sign.EnvelopeSigner{Key: pk})
+		keySigners = append(keySigners, k)
+		assert.NoError(t, db.AddKey(k.PublicData().IDs()[0], k.PublicData()))
+	}
+	signed := json.RawMessage(`{"_type": "test", "version": 1}`)
+	envelope, err := sign.SignEnvelope(signed, signers)
+	assert.NoError(t, err)
+
+	// Below, at and above the threshold.
+	assert.NoError(t, db.VerifyEnvelope(envelope, 1))
+	assert.NoError(t, db.VerifyEnvelope(envelope, 2))
+	assert.Equal(t, ErrRoleThreshold{3, 2}, db.VerifyEnvelope(envelope, 3))
+	assert.Equal(t, ErrInvalidThreshold, db.VerifyEnvelope(envelope, 0))
+
+	// Signatures by unknown keys do not count.
+	unknown, err := keys.GenerateEd25519Key()
+	assert.NoError(t, err)
+	unknownPk, err := unknown.MarshalPrivateKey()
+	assert.NoError(t, err)
+	partial, err := sign.SignEnvelope(signed, []sign.EnvelopeSigner{signers[0], {Key: unknownPk}})
+	assert.NoError(t, err)
+	assert.NoError(t, db.VerifyEnvelope(partial, 1))
+	assert.Equal(t, ErrRoleThreshold{2, 1}, db.VerifyEnvelope(partial, 2))
+
+	// Tampered signed body.
+	s := &data.Signed{}
+	assert.NoError(t, json.Unmarshal(envelope, s))
+	s.Signed = json.RawMessage(`{"_type":"test","version":2}`)
+	tampered, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrInvalid, db.VerifyEnvelope(tampered, 1))
+
+	// Duplicate key IDs.
+	assert.NoError(t, json.Unmarshal(envelope, s))
+	s.Signatures = append(s.Signatures, s.Signatures[0])
+	duplicated, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrDuplicateKeyID, db.VerifyEnvelope(duplicated, 1))
+
+	// A signature over a non-canonical form of the body.
+	raw := []byte(`{"version": 1, "_type": "test"}`)
+	sig, err := keySigners[0].SignMessage(raw)
+	assert.NoError(t, err)
+	nonCanonical, err := json.Marshal(&data.Signed{
+		Signed:     raw,
+		Signatures: []data.Signature{{KeyID: keySigners[0].PublicData().IDs()[0], Signature: sig}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrInvalid, db.VerifyEnvelope(nonCanonical, 1))
+}
+
+func TestVerifyEnvelopeKeyObjects(t *testing.T) {
+	k, err := keys.GenerateEd25519Key()
+	require.NoError(t, err)
+
+	// The same key under two key objects, which only differ in their
+	// keyid_hash_algorithms and so have unrelated key IDs.
+	db := NewDB()
+	pk := k.PublicData()
+	other := &data.PublicKey{Type: pk.Type, Scheme: pk.Scheme, Algorithms: []string{"sha256"}, Value: pk.Value}
+	var ids []string
+	for _, key := range []*data.PublicKey{pk, other} {
+		id := key.IDs()[0]
+		require.NoError(t, db.AddKey(id, key))
+		ids = append(ids, id)
+	}
+	require.NotEqual(t, ids[0], ids[1])
+
+	// Signing under both key IDs does not meet a threshold of two.
+	signed := []byte(`{"_type":"test","version":1}`)
+	sig, err := k.SignMessage(signed)
+	require.NoError(t, err)
+	envelope, err := json.Marshal(&data.Signed{
+		Signed: signed,
+		Signatures: []data.Signature{
+			{KeyID: ids[0], Signature: sig},
+			{KeyID: ids[1], Signature: sig},
+		},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, db.VerifyEnvelope(envelope, 1))
+	assert.Equal(t, ErrRoleThreshold{2, 1}, db.VerifyEnvelope(envelope, 2))
+}
+
+func TestTooManySignatures(t *testing.T) {
+	k, err := keys.GenerateEd25519Key()
+	require.NoError(t, err)
+	id := k.PublicData().IDs()[0]
+	db := NewDB()
+	require.NoError(t, db.AddKey(id, k.PublicData()))
+	require.NoError(t, db.AddRole("root", &data.Role{KeyIDs: []string{id}, Threshold: 1}))
+
+	// An invalid signature by a known key, which fails the verification
+	// if it is reached, among bogus signatures by unknown keys.
+	envelope := func(n int) *data.Signed {
+		s := &data.Signed{Signed: json.RawMessage(`{"_type":"root","version":1}`)}
+		s.Signatures = append(s.Signatures, data.Signature{KeyID: id, Signature: make([]byte, 64)})
+		for i := 1; i < n; i++ {
+			s.Signatures = append(s.Signatures, data.Signature{KeyID: fmt.Sprintf("%064x", i), Signature: make([]byte, 64)})
+		}
+		return s
+	}
+	marshal := func(s *data.Signed) []byte {
+		b, err := json.Marshal(s)
+		require.NoError(t, err)
+		return b
+	}
+
+	atLimit := envelope(DefaultMaxSignatures)
+	assert.Equal(t, ErrInvalid, db.VerifySignatures(atLimit, "root"))
+	assert.Equal(t, ErrInvalid, db.VerifyEnvelope(marshal(atLimit), 1))
+
+	aboveLimit := envelope(DefaultMaxSignatures + 1)
+	assert.Equal(t, ErrTooManySignatures, db.VerifySignatures(aboveLimit, "root"))
+	assert.Equal(t, ErrTooManySignatures, db.VerifyEnvelope(marshal(aboveLimit), 1))
+
+	// The limit is a setting of each DB.
+	unlimited := NewDB(WithMaxSignatures(0))
+	require.NoError(t, unlimited.AddKey(id, k.PublicData()))
+	require.NoError(t, unlimited.AddRole("root", &data.Role{KeyIDs: []string{id}, Threshold: 1}))
+	assert.Equal(t, ErrInvalid, unlimited.VerifySignatures(aboveLimit, "root"))
+	assert.Equal(t, ErrTooManySignatures, db.VerifySignatures(aboveLimit, "root"))
+
+	small := NewDB(WithMaxSignatures(2))
+	require.NoError(t, small.AddKey(id, k.PublicData()))
+	require.NoError(t, small.AddRole("root", &data.Role{KeyIDs: []string{id}, Threshold: 1}))
+	assert.Equal(t, ErrTooManySignatures, small.VerifySignatures(envelope(3), "root"))
+	assert.Equal(t, ErrTooManySignatures, small.VerifyEnvelope(marshal(envelope(3)), 1))
+}
+
+func TestDuplicateSignatures(t *testing.T) {
+	k, err := keys.GenerateEcdsaKey()
+	require.NoError(t, err)
+	id := k.PublicData().IDs()[0]
+	db := NewDB()
+	require.NoError(t, db.AddKey(id, k.PublicData()))
+	require.NoError(t, db.AddRole("root", &data.Role{KeyIDs: []string{id}, Threshold: 2}))
+
+	signed := json.RawMessage(`{"_type":"root","version":1}`)
+	sign := func() data.Signature {
+		sig, err := k.SignMessage(signed)
+		require.NoError(t, err)
+		return data.Signature{KeyID: id, Signature: sig}
+	}
+
+	// The same signature twice does not reach a threshold of two, and is
+	// rejected in strict mode.
+	sig := sign()
+	identical := &data.Signed{Signed: signed, Signatures: []data.Signature{sig, sig}}
+	assert.Equal(t, ErrRoleThreshold{2, 1}, db.VerifySignatures(identical, "root"))
+	assert.Equal(t, ErrDuplicateSignature, db.VerifySignaturesStrict(identical, "root"))
+
+	// Two different valid signatures by the same key count once, but are
+	// not duplicates.
+	other := sign()
+	require.NotEqual(t, sig.Signature, other.Signature)
+	distinct := &data.Signed{Signed: signed, Signatures: []data.Signature{sig, other}}
+	assert.Equal(t, ErrRoleThreshold{2, 1}, db.VerifySignatures(distinct, "root"))
+	assert.Equal(t, ErrRoleThreshold{2, 1}, db.VerifySignaturesStrict(distinct, "root"))
+
+	db.GetRole("root").Threshold = 1
+	assert.NoError(t, db.VerifySignatures(identical, "root"))
+	assert.Equal(t, ErrDuplicateSignature, db.VerifySignaturesStrict(identical, "root"))
+	assert.NoError(t, db.VerifySignaturesStrict(distinct, "root"))
+}
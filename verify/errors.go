@@ -3,6 +3,8 @@ package verify
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -18,6 +20,12 @@ var (
 	ErrInvalidDelegatedRole = errors.New("tuf: invalid delegated role")
 	ErrInvalidKeyID         = errors.New("tuf: invalid key id")
 	ErrInvalidThreshold     = errors.New("tuf: invalid role threshold")
+	ErrDuplicateKeyID       = errors.New("tuf: duplicate key id in signatures")
+	ErrAlgorithmKeyMismatch = errors.New("tuf: signature algorithm does not match the key")
+	ErrTooManySignatures    = errors.New("tuf: too many signatures")
+	ErrDuplicateSignature   = errors.New("tuf: duplicate signature")
+	ErrNilSignature         = errors.New("tuf: nil signature")
+	ErrRootKeysMismatch     = errors.New("tuf: root keys do not match the new root")
 )
 
 type ErrWrongID struct{}
@@ -26,6 +34,10 @@ func (ErrWrongID) Error() string {
 	return "tuf: key id mismatch"
 }
 
+// ErrKeyIDMismatch is returned when a signature's key ID is not one of the
+// IDs of the key used to verify it.
+var ErrKeyIDMismatch error = ErrWrongID{}
+
 type ErrUnknownRole struct {
 	Role string
 }
@@ -68,3 +80,33 @@ type ErrRoleThreshold struct {
 func (e ErrRoleThreshold) Error() string {
 	return "tuf: valid signatures did not meet threshold"
 }
+
+// ErrDistinctAlgorithms is returned when valid signatures were made with
+// fewer distinct algorithms than required.
+type ErrDistinctAlgorithms struct {
+	Expected int
+	Actual   int
+}
+
+func (e ErrDistinctAlgorithms) Error() string {
+	return fmt.Sprintf("tuf: valid signatures use %d distinct algorithms, %d required", e.Actual, e.Expected)
+}
+
+// ErrLoadKeys is returned by LoadKeysFromDir when some key files could not
+// be loaded, with the error of each of these files by path.
+type ErrLoadKeys struct {
+	Errors map[string]error
+}
+
+func (e ErrLoadKeys) Error() string {
+	paths := make([]string, 0, len(e.Errors))
+	for path := range e.Errors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	msgs := make([]string, len(paths))
+	for i, path := range paths {
+		msgs[i] = fmt.Sprintf("%s: %s", path, e.Errors[path])
+	}
+	return fmt.Sprintf("tuf: cannot load %d key files: %s", len(paths), strings.Join(msgs, "; "))
+}
@@ -0,0 +1,64 @@
+package verify
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+// LoadKeysFromDir returns a DB holding the public keys of the *.json and
+// *.pem files found in dir and its subdirectories, under each of their key
+// IDs. Files are parsed with keys.ParsePublicKey, so key objects, PEM public
+// keys and unencrypted PEM private keys are supported; other files are
+// ignored.
+//
+// A file that cannot be loaded does not stop the others from being loaded:
+// the DB is returned along with an ErrLoadKeys listing the failed files.
+func LoadKeysFromDir(dir string) (*DB, error) {
+	db := NewDB()
+	failed := make(map[string]error)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".pem":
+		default:
+			return nil
+		}
+		if err := loadKeyFile(db, path); err != nil {
+			failed[path] = err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(failed) != 0 {
+		return db, ErrLoadKeys{Errors: failed}
+	}
+	return db, nil
+}
+
+func loadKeyFile(db *DB, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	pk, err := keys.ParsePublicKey(raw)
+	if err != nil {
+		return err
+	}
+	for _, id := range pk.IDs() {
+		if err := db.AddKey(id, pk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package verify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+func writeKeyFile(t *testing.T, path string, content []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, content, 0644))
+}
+
+func TestLoadKeysFromDir(t *testing.T) {
+	dir := t.TempDir()
+
+	// A key object.
+	ed, err := keys.GenerateEd25519Key()
+	require.NoError(t, err)
+	edJSON, err := json.Marshal(ed.PublicData())
+	require.NoError(t, err)
+	writeKeyFile(t, filepath.Join(dir, "ed25519.json"), edJSON)
+
+	// A PEM public key.
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&ec.PublicKey)
+	require.NoError(t, err)
+	writeKeyFile(t, filepath.Join(dir, "ecdsa.PEM"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	ecPK, err := keys.ToPublicKey(&ec.PublicKey, nil)
+	require.NoError(t, err)
+
+	// A private key object in a subdirectory.
+	priv, err := keys.GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	require.NoError(t, err)
+	privKey, err := priv.MarshalPrivateKey()
+	require.NoError(t, err)
+	privJSON, err := json.Marshal(privKey)
+	require.NoError(t, err)
+	writeKeyFile(t, filepath.Join(dir, "private", "p384.json"), privJSON)
+
+	// Invalid key files, and a file that is not a key file.
+	writeKeyFile(t, filepath.Join(dir, "invalid.json"), []byte(`{"keytype":"ed25519","keyval":{"public":"00"}}`))
+	writeKeyFile(t, filepath.Join(dir, "invalid.pem"), []byte("-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n"))
+	writeKeyFile(t, filepath.Join(dir, "README.txt"), []byte("not a key"))
+
+	db, err := LoadKeysFromDir(dir)
+	require.NotNil(t, db)
+	var loadErr ErrLoadKeys
+	require.True(t, errors.As(err, &loadErr), "%v", err)
+	assert.Len(t, loadErr.Errors, 2)
+	assert.Contains(t, loadErr.Errors, filepath.Join(dir, "invalid.json"))
+	assert.Contains(t, loadErr.Errors, filepath.Join(dir, "invalid.pem"))
+
+	// The valid keys are loaded under their key IDs.
+	for _, pk := range []*data.PublicKey{ed.PublicData(), ecPK, priv.PublicData()} {
+		for _, id := range pk.IDs() {
+			_, err := db.GetVerifier(id)
+			assert.NoError(t, err, pk.Type)
+		}
+	}
+	msg := []byte("foo")
+	sig, err := priv.SignMessage(msg)
+	require.NoError(t, err)
+	assert.NoError(t, db.VerifySignature(msg, data.Signature{KeyID: priv.PublicData().IDs()[0], Signature: sig}))
+
+	// Without the invalid files, there is no error.
+	require.NoError(t, os.Remove(filepath.Join(dir, "invalid.json")))
+	require.NoError(t, os.Remove(filepath.Join(dir, "invalid.pem")))
+	db, err = LoadKeysFromDir(dir)
+	require.NoError(t, err)
+	_, err = db.GetVerifier(ed.PublicData().IDs()[0])
+	assert.NoError(t, err)
+
+	_, err = LoadKeysFromDir(filepath.Join(dir, "missing"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
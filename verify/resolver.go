@@ -0,0 +1,36 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+)
+
+// A KeyResolver looks up public keys by key ID, for example from a remote
+// trust service. Any caching is up to the implementation.
+type KeyResolver interface {
+	Resolve(ctx context.Context, keyID string) (*data.PublicKey, error)
+}
+
+// VerifyWithResolver verifies that sig is a valid signature of msg by the key
+// that r resolves for the signature's key ID. The resolved key must actually
+// have that key ID.
+func VerifyWithResolver(ctx context.Context, msg []byte, sig data.Signature, r KeyResolver) error {
+	k, err := r.Resolve(ctx, sig.KeyID)
+	if err != nil {
+		return fmt.Errorf("tuf: error resolving key %s: %w", sig.KeyID, err)
+	}
+	if err := CheckSignatureKeyID(&sig, k); err != nil {
+		return err
+	}
+	verifier, err := keys.GetVerifier(k)
+	if err != nil {
+		return ErrInvalidKey
+	}
+	if err := verifier.Verify(msg, sig.Signature); err != nil {
+		return ErrInvalid
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// TrustStore holds the currently trusted root keys and threshold, and
+// updates them following the TUF root rotation rule: a new root must be
+// signed by a threshold of both the current and the new root keys. It is
+// safe for concurrent use.
+type TrustStore struct {
+	mu sync.RWMutex
+	db *DB
+}
+
+// NewTrustStore returns a TrustStore trusting keys with the given threshold.
+func NewTrustStore(keys []*data.PublicKey, threshold int) (*TrustStore, error) {
+	byID := make(map[string]*data.PublicKey, len(keys))
+	for _, k := range keys {
+		byID[k.IDs()[0]] = k
+	}
+	db, err := newRootDB(byID, threshold)
+	if err != nil {
+		return nil, err
+	}
+	return &TrustStore{db: db}, nil
+}
+
+// newRootDB returns a DB holding keys, indexed by key ID, as the keys of the
+// root role.
+func newRootDB(keys map[string]*data.PublicKey, threshold int) (*DB, error) {
+	db := NewDB()
+	role := &data.Role{Threshold: threshold}
+	for id, k := range keys {
+		if err := db.AddKey(id, k); err != nil {
+			return nil, err
+		}
+		role.KeyIDs = append(role.KeyIDs, id)
+	}
+	if err := db.AddRole("root", role); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// Verify verifies that sigs contain valid signatures of the signed portion
+// of metadata signed by a threshold of the trusted root keys. It fails with
+// ErrNilSignature if sigs holds a nil signature.
+func (t *TrustStore) Verify(signed []byte, sigs []*data.Signature) error {
+	s := &data.Signed{Signed: signed, Signatures: make([]data.Signature, len(sigs))}
+	for i, sig := range sigs {
+		if sig == nil {
+			return ErrNilSignature
+		}
+		s.Signatures[i] = *sig
+	}
+	t.mu.RLock()
+	db := t.db
+	t.mu.RUnlock()
+	return db.VerifySignatures(s, "root")
+}
+
+// Rotate replaces the trusted root keys and threshold with the root keys
+// and threshold of newRoot, a signed root metadata file. newKeys and
+// newThreshold must be these keys and threshold, or Rotate fails with
+// ErrRootKeysMismatch, so that only a key set authorized by newRoot can be
+// installed. newRoot must be signed by a threshold of the current keys,
+// proving the rotation was authorized, and by a threshold of its own root
+// keys, proving the new keys are usable. The trusted keys are unchanged if
+// any check fails.
+func (t *TrustStore) Rotate(newRoot []byte, newKeys []*data.PublicKey, newThreshold int) error {
+	s := &data.Signed{}
+	if err := json.Unmarshal(newRoot, s); err != nil {
+		return err
+	}
+	rootKeys, threshold, err := rootRoleKeys(s.Signed)
+	if err != nil {
+		return err
+	}
+	if err := checkRootKeys(rootKeys, threshold, newKeys, newThreshold); err != nil {
+		return err
+	}
+	newDB, err := newRootDB(rootKeys, threshold)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.db.VerifySignatures(s, "root"); err != nil {
+		return fmt.Errorf("tuf: new root not signed by the current root keys: %w", err)
+	}
+	if err := newDB.VerifySignatures(s, "root"); err != nil {
+		return fmt.Errorf("tuf: new root not signed by the new root keys: %w", err)
+	}
+	t.db = newDB
+	return nil
+}
+
+// rootRoleKeys returns the keys of the root role of signed, a root metadata
+// file, indexed by the key IDs the file lists them under, and the threshold
+// of the role.
+func rootRoleKeys(signed []byte) (map[string]*data.PublicKey, int, error) {
+	root := &data.Root{}
+	if err := json.Unmarshal(signed, root); err != nil {
+		return nil, 0, err
+	}
+	if root.Type != "root" {
+		return nil, 0, ErrWrongMetaType
+	}
+	role, ok := root.Roles["root"]
+	if !ok {
+		return nil, 0, ErrUnknownRole{"root"}
+	}
+	keys := make(map[string]*data.PublicKey, len(role.KeyIDs))
+	for _, id := range role.KeyIDs {
+		k, ok := root.Keys[id]
+		if !ok {
+			return nil, 0, ErrMissingKey
+		}
+		keys[id] = k
+	}
+	return keys, role.Threshold, nil
+}
+
+// checkRootKeys checks that the keys and threshold given by the caller of
+// Rotate are the root keys and threshold of the new root.
+func checkRootKeys(rootKeys map[string]*data.PublicKey, threshold int, keys []*data.PublicKey, keysThreshold int) error {
+	if threshold != keysThreshold {
+		return fmt.Errorf("%w: threshold is %d, want %d", ErrRootKeysMismatch, keysThreshold, threshold)
+	}
+	want := make(map[string]struct{}, len(rootKeys))
+	for _, k := range rootKeys {
+		want[k.IDs()[0]] = struct{}{}
+	}
+	got := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		id := k.IDs()[0]
+		if _, ok := want[id]; !ok {
+			return fmt.Errorf("%w: key %s is not a root key", ErrRootKeysMismatch, id)
+		}
+		got[id] = struct{}{}
+	}
+	if len(got) != len(want) {
+		return fmt.Errorf("%w: %d root keys given, want %d", ErrRootKeysMismatch, len(got), len(want))
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+	"github.com/theupdateframework/go-tuf/sign"
+)
+
+func generateRootKeys(t *testing.T, n int) ([]sign.EnvelopeSigner, []*data.PublicKey) {
+	var signers []sign.EnvelopeSigner
+	var pks []*data.PublicKey
+	for i := 0; i < n; i++ {
+		k, err := keys.GenerateEd25519Key()
+		require.NoError(t, err)
+		pk, err := k.MarshalPrivateKey()
+		require.NoError(t, err)
+		signers = append(signers, sign.EnvelopeSigner{Key: pk})
+		pks = append(pks, k.PublicData())
+	}
+	return signers, pks
+}
+
+func TestTrustStoreVerify(t *testing.T) {
+	signers, pks := generateRootKeys(t, 3)
+	store, err := NewTrustStore(pks, 2)
+	require.NoError(t, err)
+
+	signed := json.RawMessage(`{"_type": "root", "version": 1}`)
+	envelope, err := sign.SignEnvelope(signed, signers[:2])
+	require.NoError(t, err)
+	s := &data.Signed{}
+	require.NoError(t, json.Unmarshal(envelope, s))
+	var sigs []*data.Signature
+	for i := range s.Signatures {
+		sigs = append(sigs, &s.Signatures[i])
+	}
+
+	assert.NoError(t, store.Verify(signed, sigs))
+	assert.Equal(t, ErrRoleThreshold{2, 1}, store.Verify(signed, sigs[:1]))
+	assert.Equal(t, ErrNoSignatures, store.Verify(signed, nil))
+	assert.Equal(t, ErrNilSignature, store.Verify(signed, []*data.Signature{sigs[0], nil}))
+
+	_, err = NewTrustStore(pks, 0)
+	assert.Equal(t, ErrInvalidThreshold, err)
+}
+
+// rootMetadata returns root metadata whose root role has keys and
+// threshold.
+func rootMetadata(t *testing.T, keys []*data.PublicKey, threshold int) json.RawMessage {
+	root := data.NewRoot()
+	root.Version = 2
+	role := &data.Role{Threshold: threshold}
+	for _, k := range keys {
+		root.AddKey(k)
+		role.KeyIDs = append(role.KeyIDs, k.IDs()...)
+	}
+	root.Roles["root"] = role
+	b, err := json.Marshal(root)
+	require.NoError(t, err)
+	return b
+}
+
+func TestTrustStoreRotate(t *testing.T) {
+	oldSigners, oldKeys := generateRootKeys(t, 2)
+	newSigners, newKeys := generateRootKeys(t, 2)
+	store, err := NewTrustStore(oldKeys, 2)
+	require.NoError(t, err)
+
+	signed := rootMetadata(t, newKeys, 2)
+
+	// Missing a signature by the old keys.
+	newRoot, err := sign.SignEnvelope(signed, append(oldSigners[:1:1], newSigners...))
+	require.NoError(t, err)
+	err = store.Rotate(newRoot, newKeys, 2)
+	var thresholdErr ErrRoleThreshold
+	assert.True(t, errors.As(err, &thresholdErr))
+	assert.Equal(t, ErrRoleThreshold{2, 1}, thresholdErr)
+
+	// Missing a signature by the new keys.
+	newRoot, err = sign.SignEnvelope(signed, append(oldSigners, newSigners[:1]...))
+	require.NoError(t, err)
+	assert.Error(t, store.Rotate(newRoot, newKeys, 2))
+
+	// The failed rotations left the old keys trusted.
+	oldOnly, err := sign.SignEnvelope(signed, oldSigners)
+	require.NoError(t, err)
+	s := &data.Signed{}
+	require.NoError(t, json.Unmarshal(oldOnly, s))
+	assert.NoError(t, store.Verify(s.Signed, []*data.Signature{&s.Signatures[0], &s.Signatures[1]}))
+
+	// Valid rotation, after which only the new keys are trusted.
+	newRoot, err = sign.SignEnvelope(signed, append(oldSigners, newSigners...))
+	require.NoError(t, err)
+	require.NoError(t, store.Rotate(newRoot, newKeys, 2))
+	assert.Equal(t, ErrRoleThreshold{2, 0}, store.Verify(s.Signed, []*data.Signature{&s.Signatures[0], &s.Signatures[1]}))
+
+	newOnly, err := sign.SignEnvelope(signed, newSigners)
+	require.NoError(t, err)
+	s = &data.Signed{}
+	require.NoError(t, json.Unmarshal(newOnly, s))
+	assert.NoError(t, store.Verify(s.Signed, []*data.Signature{&s.Signatures[0], &s.Signatures[1]}))
+}
+
+func TestTrustStoreRotateKeysFromRoot(t *testing.T) {
+	oldSigners, oldKeys := generateRootKeys(t, 2)
+	newSigners, newKeys := generateRootKeys(t, 2)
+	_, otherKeys := generateRootKeys(t, 1)
+	store, err := NewTrustStore(oldKeys, 2)
+	require.NoError(t, err)
+
+	newRoot, err := sign.SignEnvelope(rootMetadata(t, newKeys, 2), append(oldSigners, newSigners...))
+	require.NoError(t, err)
+
+	// Keys or a threshold other than the ones of the new root, although
+	// signed by all the keys, cannot be installed.
+	for _, c := range []struct {
+		keys      []*data.PublicKey
+		threshold int
+	}{
+		{newKeys, 1},
+		{newKeys[:1], 2},
+		{append(newKeys, otherKeys...), 2},
+		{append(newKeys[:1:1], otherKeys...), 2},
+	} {
+		assert.True(t, errors.Is(store.Rotate(newRoot, c.keys, c.threshold), ErrRootKeysMismatch))
+	}
+
+	// Metadata other than a root with a root role.
+	notRoot, err := sign.SignEnvelope(json.RawMessage(`{"_type":"targets","version":2}`), append(oldSigners, newSigners...))
+	require.NoError(t, err)
+	assert.Equal(t, ErrWrongMetaType, store.Rotate(notRoot, newKeys, 2))
+	noRole := data.NewRoot()
+	noRoleJSON, err := json.Marshal(noRole)
+	require.NoError(t, err)
+	noRoleRoot, err := sign.SignEnvelope(noRoleJSON, append(oldSigners, newSigners...))
+	require.NoError(t, err)
+	assert.Equal(t, ErrUnknownRole{"root"}, store.Rotate(noRoleRoot, newKeys, 2))
+
+	require.NoError(t, store.Rotate(newRoot, newKeys, 2))
+}
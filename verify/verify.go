@@ -2,12 +2,15 @@ package verify
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/secure-systems-lab/go-securesystemslib/cjson"
 	"github.com/theupdateframework/go-tuf/data"
 	"github.com/theupdateframework/go-tuf/internal/roles"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
 )
 
 type signedMeta struct {
@@ -70,10 +73,38 @@ var IsExpired = func(t time.Time) bool {
 	return time.Until(t) <= 0
 }
 
-func (db *DB) VerifySignatures(s *data.Signed, role string) error {
-	if len(s.Signatures) == 0 {
+// DefaultMaxSignatures is the number of signatures a DB accepts by default,
+// see WithMaxSignatures.
+const DefaultMaxSignatures = 256
+
+func (db *DB) checkSignatureCount(n int) error {
+	if n == 0 {
 		return ErrNoSignatures
 	}
+	if db.maxSignatures > 0 && n > db.maxSignatures {
+		return ErrTooManySignatures
+	}
+	return nil
+}
+
+// VerifySignatures verifies that a threshold of the keys of role signed s.
+// Each key counts once, however many signatures it made, and byte-identical
+// signatures are only verified once.
+func (db *DB) VerifySignatures(s *data.Signed, role string) error {
+	return db.verifySignatures(s, role, false)
+}
+
+// VerifySignaturesStrict is like VerifySignatures, but fails with
+// ErrDuplicateSignature if s holds the same signature with the same key ID
+// more than once, which honest signers never produce.
+func (db *DB) VerifySignaturesStrict(s *data.Signed, role string) error {
+	return db.verifySignatures(s, role, true)
+}
+
+func (db *DB) verifySignatures(s *data.Signed, role string, strict bool) error {
+	if err := db.checkSignatureCount(len(s.Signatures)); err != nil {
+		return err
+	}
 
 	roleData := db.GetRole(role)
 	if roleData == nil {
@@ -93,8 +124,18 @@ func (db *DB) VerifySignatures(s *data.Signed, role string) error {
 	// multiple key ids, we need to protect against multiple attached
 	// signatures that just differ on the key id.
 	seen := make(map[string]struct{})
+	sigs := make(map[string]struct{}, len(s.Signatures))
 	valid := 0
 	for _, sig := range s.Signatures {
+		pair := sig.KeyID + ":" + sig.Signature.String()
+		if _, ok := sigs[pair]; ok {
+			if strict {
+				return ErrDuplicateSignature
+			}
+			continue
+		}
+		sigs[pair] = struct{}{}
+
 		if !roleData.ValidKey(sig.KeyID) {
 			continue
 		}
@@ -162,3 +203,201 @@ func (db *DB) UnmarshalTrusted(b []byte, v interface{}, role string) error {
 	}
 	return json.Unmarshal(s.Signed, v)
 }
+
+// VerifyAll verifies that every signature in sigs is a valid signature of msg
+// by the key with the signature's key ID in pubKeys. Unlike VerifySignatures,
+// which only requires a threshold of valid signatures, a single missing key
+// or invalid signature fails the verification. This is intended for
+// policies requiring several signatures of different kinds to all be
+// present, such as dual classical and post-quantum signatures.
+func VerifyAll(msg []byte, sigs []data.Signature, pubKeys map[string]*data.PublicKey) error {
+	if len(sigs) == 0 {
+		return ErrNoSignatures
+	}
+	for _, sig := range sigs {
+		k, ok := pubKeys[sig.KeyID]
+		if !ok {
+			return ErrMissingKey
+		}
+		if err := CheckSignatureKeyID(&sig, k); err != nil {
+			return err
+		}
+		verifier, err := keys.GetVerifier(k)
+		if err != nil {
+			return ErrInvalidKey
+		}
+		if err := verifier.Verify(msg, sig.Signature); err != nil {
+			return ErrInvalid
+		}
+	}
+	return nil
+}
+
+// VerifyDistinctAlgorithms verifies sigs over msg with the keys in db and
+// requires the valid signatures to use at least minDistinct distinct
+// algorithms, so that a break of a single algorithm is not enough to forge
+// them. Algorithms are counted by family, as returned by keys.KeyFamily:
+// ed25519, ecdsa and rsa. Keys of the same family on different curves or
+// with different hash functions or signature variants, such as P-256 and
+// P-384 ECDSA keys or Ed25519 and Ed25519ph keys, rely on the same
+// underlying problem and count as a single algorithm. Signatures by keys
+// that are not in db and invalid signatures are not counted. It returns
+// ErrDistinctAlgorithms if too few algorithms are found, and the error of
+// keys.KeyFamily if the family of a key cannot be told.
+func (db *DB) VerifyDistinctAlgorithms(msg []byte, sigs []*data.Signature, minDistinct int) error {
+	if minDistinct < 1 {
+		return ErrInvalidThreshold
+	}
+	if err := db.checkSignatureCount(len(sigs)); err != nil {
+		return err
+	}
+	algorithms := make(map[string]struct{})
+	for _, sig := range sigs {
+		if sig == nil || db.VerifySignature(msg, *sig) != nil {
+			continue
+		}
+		verifier, _ := db.GetVerifier(sig.KeyID)
+		family, err := keys.KeyFamily(verifier)
+		if err != nil {
+			return err
+		}
+		algorithms[family] = struct{}{}
+	}
+	if len(algorithms) < minDistinct {
+		return ErrDistinctAlgorithms{Expected: minDistinct, Actual: len(algorithms)}
+	}
+	return nil
+}
+
+// CountValidSignatures verifies sigs over msg with the keys in db and
+// returns the number of distinct keys with a valid signature, along with the
+// result of the verification for each key ID of sigs: nil for valid
+// signatures, ErrInvalid for invalid ones and ErrMissingKey for key IDs that
+// are not in db. It does not apply any threshold, for reporting how many of
+// the signatures are valid. A key ID with several signatures is valid if any
+// of them is. Like in VerifySignatures a key counts once even if it signed
+// under several of its key IDs, and so does a key added to db under several
+// key objects, such as with different keyid_hash_algorithms.
+//
+// Like VerifySignatures, it fails with ErrNoSignatures or
+// ErrTooManySignatures if sigs is empty or holds more signatures than the
+// limit of db.
+//
+// CountValidSignatures is a method of DB, which is the keyring of this
+// package, rather than a function taking a separate keyring type, and it
+// returns an error, unlike the counting-only helper first requested, so
+// that it applies the same signature count limits as VerifySignatures.
+func (db *DB) CountValidSignatures(msg []byte, sigs []*data.Signature) (valid int, results map[string]error, err error) {
+	if err := db.checkSignatureCount(len(sigs)); err != nil {
+		return 0, nil, err
+	}
+	results = make(map[string]error, len(sigs))
+	seen := make(map[string]struct{})
+	for _, sig := range sigs {
+		if sig == nil {
+			continue
+		}
+		if err, ok := results[sig.KeyID]; ok && err == nil {
+			continue
+		}
+		verifier, err := db.GetVerifier(sig.KeyID)
+		if err != nil {
+			results[sig.KeyID] = fmt.Errorf("%w: %s", err, sig.KeyID)
+			continue
+		}
+		if err := verifier.Verify(msg, sig.Signature); err != nil {
+			results[sig.KeyID] = ErrInvalid
+			continue
+		}
+		results[sig.KeyID] = nil
+		_, seenID := seen[sig.KeyID]
+		_, seenKey := seen[verifier.Public()]
+		if !seenID && !seenKey {
+			for _, id := range verifier.MarshalPublicKey().IDs() {
+				seen[id] = struct{}{}
+			}
+			seen[verifier.Public()] = struct{}{}
+			valid++
+		}
+	}
+	return valid, results, nil
+}
+
+// VerifyStrict verifies that sig is a valid signature of msg by pk, only
+// using the signature scheme declared by the key. Keys without a scheme, or
+// with a scheme that is not registered for their key type, and signatures
+// without the size or structure of that scheme fail with
+// ErrAlgorithmKeyMismatch, as does a signature key ID that is not an ID of
+// pk. No other scheme or signature format is tried.
+func VerifyStrict(msg []byte, sig *data.Signature, pk *data.PublicKey) error {
+	if err := CheckSignatureKeyID(sig, pk); err != nil {
+		return fmt.Errorf("%w: %s", ErrAlgorithmKeyMismatch, err)
+	}
+	schemes, err := keys.CandidateSchemes(pk)
+	if err != nil {
+		return ErrInvalidKey
+	}
+	if pk.Scheme == "" || !containsString(schemes, pk.Scheme) {
+		return fmt.Errorf("%w: scheme %q for key type %q", ErrAlgorithmKeyMismatch, pk.Scheme, pk.Type)
+	}
+	// The structure of signatures of custom key types is unknown.
+	if err := keys.ValidateSignatureFormat(pk.Scheme, sig.Signature, nil); err != nil && !errors.Is(err, keys.ErrUnsupportedKeyType) {
+		return fmt.Errorf("%w: %s", ErrAlgorithmKeyMismatch, err)
+	}
+	verifier, err := keys.GetVerifier(pk)
+	if err != nil {
+		return ErrInvalidKey
+	}
+	if err := verifier.Verify(msg, sig.Signature); err != nil {
+		return ErrInvalid
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IdentifySigner returns the primary key ID of the first key of candidates
+// for which signature is a valid signature of msg, regardless of the key ID
+// the signature claims. This is meant to attribute signatures to keys, not
+// to verify them against a policy. Candidates of unsupported key types are
+// skipped. It returns ErrInvalid if no candidate verifies the signature.
+func IdentifySigner(msg, signature []byte, candidates []*data.PublicKey) (string, error) {
+	for _, k := range candidates {
+		verifier, err := keys.GetVerifier(k)
+		if err != nil {
+			continue
+		}
+		if verifier.Verify(msg, signature) == nil {
+			return k.IDs()[0], nil
+		}
+	}
+	return "", ErrInvalid
+}
+
+// CheckSignatureKeyID checks that sig's key ID is one of the IDs of pk, so
+// that a signature cannot be attributed to a key it does not belong to. It
+// returns ErrKeyIDMismatch otherwise.
+//
+// The IDs are recomputed from the key fields rather than read from the IDs
+// cached in pk, which would be stale if pk was modified after they were
+// first computed.
+func CheckSignatureKeyID(sig *data.Signature, pk *data.PublicKey) error {
+	fresh := &data.PublicKey{
+		Type:       pk.Type,
+		Scheme:     pk.Scheme,
+		Algorithms: pk.Algorithms,
+		Value:      pk.Value,
+		Expires:    pk.Expires,
+	}
+	if !fresh.ContainsID(sig.KeyID) {
+		return ErrKeyIDMismatch
+	}
+	return nil
+}
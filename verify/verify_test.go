@@ -1,6 +1,7 @@
 package verify
 
 import (
+	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -8,6 +9,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -290,3 +292,314 @@ func assertErrExpired(c *C, err error, expected ErrExpired) {
 	}
 	c.Assert(actual.Expired.Unix(), Equals, expected.Expired.Unix())
 }
+
+func (VerifySuite) TestVerifyAll(c *C) {
+	ed25519Key, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ecdsaKey, err := keys.GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	pubKeys := make(map[string]*data.PublicKey)
+	var sigs []data.Signature
+	for _, k := range []keys.Signer{ed25519Key, ecdsaKey} {
+		sig, err := k.SignMessage(msg)
+		c.Assert(err, IsNil)
+		id := k.PublicData().IDs()[0]
+		pubKeys[id] = k.PublicData()
+		sigs = append(sigs, data.Signature{KeyID: id, Signature: sig})
+	}
+
+	// Both signatures valid.
+	c.Assert(VerifyAll(msg, sigs, pubKeys), IsNil)
+
+	// One of the two signatures is invalid.
+	invalid := []data.Signature{sigs[0], {KeyID: sigs[1].KeyID, Signature: sigs[0].Signature}}
+	c.Assert(VerifyAll(msg, invalid, pubKeys), Equals, ErrInvalid)
+	c.Assert(VerifyAll([]byte("bar"), sigs, pubKeys), Equals, ErrInvalid)
+
+	// A key is missing.
+	delete(pubKeys, sigs[1].KeyID)
+	c.Assert(VerifyAll(msg, sigs, pubKeys), Equals, ErrMissingKey)
+
+	c.Assert(VerifyAll(msg, nil, pubKeys), Equals, ErrNoSignatures)
+}
+
+func (VerifySuite) TestVerifyDistinctAlgorithms(c *C) {
+	ed25519Key, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	otherEd25519Key, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ecdsaKey, err := keys.GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	db := NewDB()
+	sigs := make(map[keys.Signer]*data.Signature)
+	for _, k := range []keys.Signer{ed25519Key, otherEd25519Key, ecdsaKey} {
+		id := k.PublicData().IDs()[0]
+		c.Assert(db.AddKey(id, k.PublicData()), IsNil)
+		sig, err := k.SignMessage(msg)
+		c.Assert(err, IsNil)
+		sigs[k] = &data.Signature{KeyID: id, Signature: sig}
+	}
+
+	// Two valid signatures with the same algorithm.
+	sameAlgorithm := []*data.Signature{sigs[ed25519Key], sigs[otherEd25519Key]}
+	c.Assert(db.VerifyDistinctAlgorithms(msg, sameAlgorithm, 1), IsNil)
+	c.Assert(db.VerifyDistinctAlgorithms(msg, sameAlgorithm, 2), DeepEquals, ErrDistinctAlgorithms{Expected: 2, Actual: 1})
+
+	// One ed25519 and one ecdsa signature.
+	distinct := []*data.Signature{sigs[ed25519Key], sigs[ecdsaKey]}
+	c.Assert(db.VerifyDistinctAlgorithms(msg, distinct, 2), IsNil)
+	c.Assert(db.VerifyDistinctAlgorithms(msg, distinct, 3), DeepEquals, ErrDistinctAlgorithms{Expected: 3, Actual: 2})
+
+	// Invalid signatures and signatures by unknown keys are not counted.
+	c.Assert(db.VerifyDistinctAlgorithms([]byte("bar"), distinct, 1), DeepEquals, ErrDistinctAlgorithms{Expected: 1, Actual: 0})
+	invalid := []*data.Signature{sigs[ed25519Key], {KeyID: sigs[ecdsaKey].KeyID, Signature: sigs[ed25519Key].Signature}}
+	c.Assert(db.VerifyDistinctAlgorithms(msg, invalid, 2), DeepEquals, ErrDistinctAlgorithms{Expected: 2, Actual: 1})
+	unknown := []*data.Signature{sigs[ed25519Key], {KeyID: "unknown", Signature: sigs[ecdsaKey].Signature}}
+	c.Assert(db.VerifyDistinctAlgorithms(msg, unknown, 2), DeepEquals, ErrDistinctAlgorithms{Expected: 2, Actual: 1})
+
+	// ECDSA keys on different curves are of the same family.
+	p384Key, err := keys.GenerateEcdsaKeyWithType(data.KeyTypeECDSA_SHA2_P384)
+	c.Assert(err, IsNil)
+	p384ID := p384Key.PublicData().IDs()[0]
+	c.Assert(db.AddKey(p384ID, p384Key.PublicData()), IsNil)
+	p384Sig, err := p384Key.SignMessage(msg)
+	c.Assert(err, IsNil)
+	curves := []*data.Signature{sigs[ecdsaKey], {KeyID: p384ID, Signature: p384Sig}}
+	c.Assert(db.VerifyDistinctAlgorithms(msg, curves, 2), DeepEquals, ErrDistinctAlgorithms{Expected: 2, Actual: 1})
+
+	c.Assert(db.VerifyDistinctAlgorithms(msg, nil, 1), Equals, ErrNoSignatures)
+	c.Assert(db.VerifyDistinctAlgorithms(msg, distinct, 0), Equals, ErrInvalidThreshold)
+}
+
+func (VerifySuite) TestCountValidSignatures(c *C) {
+	msg := []byte("foo")
+	db := NewDB()
+	var sigs []*data.Signature
+	for i := 0; i < 3; i++ {
+		k, err := keys.GenerateEd25519Key()
+		c.Assert(err, IsNil)
+		id := k.PublicData().IDs()[0]
+		c.Assert(db.AddKey(id, k.PublicData()), IsNil)
+		sig, err := k.SignMessage(msg)
+		c.Assert(err, IsNil)
+		sigs = append(sigs, &data.Signature{KeyID: id, Signature: sig})
+	}
+	unknown, err := keys.GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	unknownSig, err := unknown.SignMessage(msg)
+	c.Assert(err, IsNil)
+	unknownID := unknown.PublicData().IDs()[0]
+
+	// Two valid signatures, one invalid and one by an unknown key.
+	invalid := &data.Signature{KeyID: sigs[2].KeyID, Signature: sigs[0].Signature}
+	mixed := []*data.Signature{sigs[0], sigs[1], invalid, {KeyID: unknownID, Signature: unknownSig}}
+	valid, results, err := db.CountValidSignatures(msg, mixed)
+	c.Assert(err, IsNil)
+	c.Assert(valid, Equals, 2)
+	c.Assert(results, HasLen, 4)
+	c.Assert(results[sigs[0].KeyID], IsNil)
+	c.Assert(results[sigs[1].KeyID], IsNil)
+	c.Assert(results[sigs[2].KeyID], Equals, ErrInvalid)
+	c.Assert(errors.Is(results[unknownID], ErrMissingKey), Equals, true)
+
+	// A key ID counts once, and is valid if any of its signatures is.
+	valid, results, err = db.CountValidSignatures(msg, []*data.Signature{sigs[0], sigs[0], invalid, sigs[2]})
+	c.Assert(err, IsNil)
+	c.Assert(valid, Equals, 2)
+	c.Assert(results, HasLen, 2)
+	c.Assert(results[sigs[2].KeyID], IsNil)
+
+	valid, results, err = db.CountValidSignatures([]byte("bar"), sigs)
+	c.Assert(err, IsNil)
+	c.Assert(valid, Equals, 0)
+	c.Assert(results, HasLen, 3)
+
+	_, _, err = db.CountValidSignatures(msg, nil)
+	c.Assert(err, Equals, ErrNoSignatures)
+	_, _, err = NewDB(WithMaxSignatures(2)).CountValidSignatures(msg, sigs)
+	c.Assert(err, Equals, ErrTooManySignatures)
+}
+
+func (VerifySuite) TestCountValidSignaturesKeyIDs(c *C) {
+	msg := []byte("foo")
+	db := NewDB()
+	k, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	sig, err := k.SignMessage(msg)
+	c.Assert(err, IsNil)
+
+	// The same key under two key objects, which only differ in their
+	// keyid_hash_algorithms and so have different key IDs.
+	pk := k.PublicData()
+	other := &data.PublicKey{Type: pk.Type, Scheme: pk.Scheme, Algorithms: []string{"sha256"}, Value: pk.Value}
+	var ids []string
+	for _, key := range []*data.PublicKey{pk, other} {
+		id := key.IDs()[0]
+		c.Assert(db.AddKey(id, key), IsNil)
+		ids = append(ids, id)
+	}
+	c.Assert(ids[0], Not(Equals), ids[1])
+
+	// The key signed under both of its key IDs, which are both valid but
+	// count as a single key.
+	valid, results, err := db.CountValidSignatures(msg, []*data.Signature{
+		{KeyID: ids[0], Signature: sig},
+		{KeyID: ids[1], Signature: sig},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(valid, Equals, 1)
+	c.Assert(results, HasLen, 2)
+	c.Assert(results[ids[0]], IsNil)
+	c.Assert(results[ids[1]], IsNil)
+}
+
+type mapKeyResolver map[string]*data.PublicKey
+
+func (m mapKeyResolver) Resolve(ctx context.Context, keyID string) (*data.PublicKey, error) {
+	k, ok := m[keyID]
+	if !ok {
+		return nil, ErrMissingKey
+	}
+	return k, nil
+}
+
+func (VerifySuite) TestVerifyWithResolver(c *C) {
+	signer, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	other, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	id := signer.PublicData().IDs()[0]
+	resolver := mapKeyResolver{id: signer.PublicData()}
+
+	msg := []byte("foo")
+	sigBytes, err := signer.SignMessage(msg)
+	c.Assert(err, IsNil)
+	sig := data.Signature{KeyID: id, Signature: sigBytes}
+	ctx := context.Background()
+
+	c.Assert(VerifyWithResolver(ctx, msg, sig, resolver), IsNil)
+	c.Assert(VerifyWithResolver(ctx, []byte("bar"), sig, resolver), Equals, ErrInvalid)
+
+	// Unknown key ID.
+	unknown := data.Signature{KeyID: other.PublicData().IDs()[0], Signature: sigBytes}
+	err = VerifyWithResolver(ctx, msg, unknown, resolver)
+	c.Assert(errors.Is(err, ErrMissingKey), Equals, true)
+
+	// The resolver returns a key with another ID.
+	resolver[unknown.KeyID] = signer.PublicData()
+	c.Assert(VerifyWithResolver(ctx, msg, unknown, resolver), Equals, ErrWrongID{})
+}
+
+func (VerifySuite) TestCheckSignatureKeyID(c *C) {
+	signer, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pk := signer.PublicData()
+	sigBytes, err := signer.SignMessage([]byte("foo"))
+	c.Assert(err, IsNil)
+
+	sig := &data.Signature{KeyID: pk.IDs()[0], Signature: sigBytes}
+	c.Assert(CheckSignatureKeyID(sig, pk), IsNil)
+
+	// Tampered key ID.
+	tampered := &data.Signature{KeyID: strings.Repeat("0", 64), Signature: sigBytes}
+	c.Assert(CheckSignatureKeyID(tampered, pk), Equals, ErrKeyIDMismatch)
+
+	// The key was swapped after its IDs were cached.
+	other, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	pk.Value = other.PublicData().Value
+	c.Assert(pk.ContainsID(sig.KeyID), Equals, true)
+	c.Assert(CheckSignatureKeyID(sig, pk), Equals, ErrKeyIDMismatch)
+}
+
+func (VerifySuite) TestIdentifySigner(c *C) {
+	var signers []keys.Signer
+	var candidates []*data.PublicKey
+	for i := 0; i < 3; i++ {
+		signer, err := keys.GenerateEd25519Key()
+		c.Assert(err, IsNil)
+		signers = append(signers, signer)
+		candidates = append(candidates, signer.PublicData())
+	}
+	// Candidates of unsupported key types are skipped.
+	candidates = append([]*data.PublicKey{{Type: "foo"}}, candidates...)
+
+	msg := []byte("foo")
+	for _, signer := range signers {
+		sig, err := signer.SignMessage(msg)
+		c.Assert(err, IsNil)
+		id, err := IdentifySigner(msg, sig, candidates)
+		c.Assert(err, IsNil)
+		c.Assert(id, Equals, signer.PublicData().IDs()[0])
+
+		_, err = IdentifySigner([]byte("bar"), sig, candidates)
+		c.Assert(err, Equals, ErrInvalid)
+	}
+
+	other, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	sig, err := other.SignMessage(msg)
+	c.Assert(err, IsNil)
+	_, err = IdentifySigner(msg, sig, candidates)
+	c.Assert(err, Equals, ErrInvalid)
+	_, err = IdentifySigner(msg, sig, nil)
+	c.Assert(err, Equals, ErrInvalid)
+}
+
+func (VerifySuite) TestVerifyStrict(c *C) {
+	ed25519Key, err := keys.GenerateEd25519Key()
+	c.Assert(err, IsNil)
+	ecdsaKey, err := keys.GenerateEcdsaKey()
+	c.Assert(err, IsNil)
+	rsaKey, err := keys.GenerateRsaKey()
+	c.Assert(err, IsNil)
+
+	msg := []byte("foo")
+	sigs := make(map[keys.Signer][]byte)
+	for _, k := range []keys.Signer{ed25519Key, ecdsaKey, rsaKey} {
+		sig, err := k.SignMessage(msg)
+		c.Assert(err, IsNil)
+		sigs[k] = sig
+		pk := k.PublicData()
+		c.Assert(VerifyStrict(msg, &data.Signature{KeyID: pk.IDs()[0], Signature: sig}, pk), IsNil)
+		c.Assert(VerifyStrict([]byte("bar"), &data.Signature{KeyID: pk.IDs()[0], Signature: sig}, pk), Equals, ErrInvalid)
+	}
+
+	// Signatures of another algorithm than the one of the key.
+	for _, t := range []struct {
+		key keys.Signer
+		sig []byte
+	}{
+		{ecdsaKey, sigs[ed25519Key]},
+		{rsaKey, sigs[ecdsaKey]},
+		{ed25519Key, sigs[rsaKey]},
+		{ed25519Key, sigs[ecdsaKey]},
+	} {
+		pk := t.key.PublicData()
+		err := VerifyStrict(msg, &data.Signature{KeyID: pk.IDs()[0], Signature: t.sig}, pk)
+		c.Assert(errors.Is(err, ErrAlgorithmKeyMismatch), Equals, true, Commentf("key type %s", pk.Type))
+	}
+
+	// Raw ECDSA signatures are not tried.
+	ecdsaPk := ecdsaKey.PublicData()
+	raw, err := keys.EcdsaDERToRaw(sigs[ecdsaKey], 32)
+	c.Assert(err, IsNil)
+	err = VerifyStrict(msg, &data.Signature{KeyID: ecdsaPk.IDs()[0], Signature: raw}, ecdsaPk)
+	c.Assert(errors.Is(err, ErrAlgorithmKeyMismatch), Equals, true)
+
+	// Keys declaring no scheme or the scheme of another key type.
+	for _, scheme := range []string{"", data.KeySchemeEd25519, data.KeySchemeECDSA_SHA2_P384} {
+		pk := ecdsaKey.PublicData()
+		pk.Scheme = scheme
+		err := VerifyStrict(msg, &data.Signature{KeyID: pk.IDs()[0], Signature: sigs[ecdsaKey]}, pk)
+		c.Assert(errors.Is(err, ErrAlgorithmKeyMismatch), Equals, true, Commentf("scheme %q", scheme))
+	}
+
+	// Signature key ID of another key.
+	err = VerifyStrict(msg, &data.Signature{KeyID: ed25519Key.PublicData().IDs()[0], Signature: sigs[ecdsaKey]}, ecdsaPk)
+	c.Assert(errors.Is(err, ErrAlgorithmKeyMismatch), Equals, true)
+}